@@ -84,6 +84,8 @@ var preciseProgress bool
 
 var monitorHz int
 
+var logFile *os.File
+
 func run() {
 	defer func() {
 		if err := recover(); err != nil {
@@ -153,6 +155,8 @@ func run() {
 
 		flag.BoolVar(&preciseProgress, "preciseprogress", false, "Show rendering progress in 1% increments")
 
+		control := flag.Bool("control", false, "Accept JSON commands (pause, resume, seek, speed, query-score) on stdin and respond on stdout. Logs are moved to stderr. Ignored in record/screenshot modes.")
+
 		flag.Parse()
 
 		var knockoutReplays []string
@@ -246,6 +250,11 @@ func run() {
 		settings.END = *end
 		settings.RECORD = recordMode || screenshotMode
 		settings.LOCALOFFSET = *offset
+		settings.CONTROL = *control && !settings.RECORD
+
+		if settings.CONTROL {
+			log.SetOutput(io.MultiWriter(os.Stderr, logFile))
+		}
 
 		if *settingsVersion == "credentials" || *settingsVersion == "launcher" {
 			panic(fmt.Sprintf("flag -settings: name \"%s\" is forbidden", *settingsVersion))
@@ -823,6 +832,8 @@ func Run() {
 		panic(err)
 	}
 
+	logFile = file
+
 	log.SetOutput(file)
 
 	printPlatformInfo()
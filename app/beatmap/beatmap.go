@@ -42,6 +42,7 @@ type BeatMap struct {
 
 	Stars        float64
 	StarsVersion int
+	AimTapRatio  float64
 
 	Length   int
 	Circles  int
@@ -61,6 +62,8 @@ type BeatMap struct {
 	ARSpecified bool
 
 	LocalOffset int
+
+	densityCache map[int64][]float64
 }
 
 func NewBeatMap() *BeatMap {
@@ -69,6 +72,7 @@ func NewBeatMap() *BeatMap {
 		StackLeniency: 0.7,
 		Diff:          difficulty.NewDifficulty(5, 5, 5, 5),
 		Stars:         -1,
+		AimTapRatio:   -1,
 		MinBPM:        math.Inf(0),
 		MaxBPM:        0,
 	}
@@ -141,6 +145,35 @@ func (beatMap *BeatMap) GetObjectsCopy() []objects.IHitObject {
 	return objs
 }
 
+// GetObjectDensity returns number of objects starting in each consecutive window of windowMs milliseconds, beginning at the first object.
+// Results are cached per window size, returned slice must not be modified.
+func (beatMap *BeatMap) GetObjectDensity(windowMs int64) []float64 {
+	if windowMs <= 0 || len(beatMap.HitObjects) == 0 {
+		return nil
+	}
+
+	if density, ok := beatMap.densityCache[windowMs]; ok {
+		return density
+	}
+
+	start := beatMap.HitObjects[0].GetStartTime()
+	end := beatMap.HitObjects[len(beatMap.HitObjects)-1].GetStartTime()
+
+	density := make([]float64, int64(end-start)/windowMs+1)
+
+	for _, o := range beatMap.HitObjects {
+		density[int64(o.GetStartTime()-start)/windowMs]++
+	}
+
+	if beatMap.densityCache == nil {
+		beatMap.densityCache = make(map[int64][]float64)
+	}
+
+	beatMap.densityCache[windowMs] = density
+
+	return density
+}
+
 func (beatMap *BeatMap) ParsePoint(point string) {
 	line := strings.Split(point, ",")
 	pointTime, _ := strconv.ParseFloat(line[0], 64)
@@ -0,0 +1,44 @@
+package beatmap
+
+import (
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap/objects"
+	"github.com/wieku/danser-go/framework/math/vector"
+)
+
+func TestGetObjectDensity(t *testing.T) {
+	beatMap := NewBeatMap()
+
+	// an object every second with a cluster of 8 objects 50ms apart in the 6th second
+	for i := 0; i <= 10; i++ {
+		beatMap.HitObjects = append(beatMap.HitObjects, objects.DummyCircle(vector.NewVec2f(256, 192), float64(i*1000)))
+
+		if i == 5 {
+			for j := 0; j < 8; j++ {
+				beatMap.HitObjects = append(beatMap.HitObjects, objects.DummyCircle(vector.NewVec2f(256, 192), float64(5100+j*50)))
+			}
+		}
+	}
+
+	density := beatMap.GetObjectDensity(1000)
+
+	if len(density) != 11 {
+		t.Fatalf("expected 11 windows, got %d", len(density))
+	}
+
+	for i, d := range density {
+		expected := 1.0
+		if i == 5 {
+			expected = 9
+		}
+
+		if d != expected {
+			t.Errorf("window %d: expected %.0f objects, got %.0f", i, expected, d)
+		}
+	}
+
+	if cached := beatMap.GetObjectDensity(1000); &cached[0] != &density[0] {
+		t.Error("density wasn't cached")
+	}
+}
@@ -41,6 +41,8 @@ type Difficulty struct {
 	Hit100 int64
 	Hit300 int64
 
+	customWindows [3]int64 // hit300, hit100 and hit50 set with SetHitWindows, zeroes if OD-derived windows are used
+
 	HPMod        float64
 	SpinnerRatio float64
 	Speed        float64
@@ -107,6 +109,10 @@ func (diff *Difficulty) calculate() {
 	diff.Hit100 = int64(diff.Hit100U)
 	diff.Hit300 = int64(diff.Hit300U)
 
+	if diff.customWindows[0] > 0 {
+		diff.Hit300, diff.Hit100, diff.Hit50 = diff.customWindows[0], diff.customWindows[1], diff.customWindows[2]
+	}
+
 	diff.SpinnerRatio = DifficultyRate(od, 3, 5, 7.5)
 	diff.Speed = 1.0 / diff.GetModifiedTime(1)
 
@@ -114,6 +120,20 @@ func (diff *Difficulty) calculate() {
 	diff.ODReal = DiffFromRate(diff.GetModifiedTime(diff.Hit300U), 80, 50, 20)
 }
 
+// SetHitWindows overrides OD-derived hit windows used for judgement, they have to satisfy 0 < hit300 <= hit100 <= hit50 <= HittableRange.
+// Unrounded windows used by difficulty calculation are left intact. Override is kept when mods or stats change.
+func (diff *Difficulty) SetHitWindows(hit300, hit100, hit50 int64) error {
+	if hit300 <= 0 || hit300 > hit100 || hit100 > hit50 || hit50 > HittableRange {
+		return fmt.Errorf("hit windows have to be increasing and within (0, %d]ms, got 300: %dms, 100: %dms, 50: %dms", int64(HittableRange), hit300, hit100, hit50)
+	}
+
+	diff.customWindows = [3]int64{hit300, hit100, hit50}
+
+	diff.calculate()
+
+	return nil
+}
+
 func (diff *Difficulty) SetMods(mods Modifier) {
 	diff.Mods = mods
 	diff.calculate()
@@ -0,0 +1,65 @@
+package difficulty
+
+import "testing"
+
+// judge returns which window a hit offset falls into: 300, 100, 50 or 0 for a miss
+func judge(diff *Difficulty, offset int64) int {
+	if offset < 0 {
+		offset = -offset
+	}
+
+	switch {
+	case offset < diff.Hit300:
+		return 300
+	case offset < diff.Hit100:
+		return 100
+	case offset < diff.Hit50:
+		return 50
+	}
+
+	return 0
+}
+
+func TestSetHitWindows(t *testing.T) {
+	const offset = 30
+
+	diff := NewDifficulty(5, 4, 8, 9)
+
+	if result := judge(diff, offset); result != 300 {
+		t.Fatalf("expected %dms offset to be a 300 on OD8, got %d", offset, result)
+	}
+
+	if err := diff.SetHitWindows(20, 60, 100); err != nil {
+		t.Fatal(err)
+	}
+
+	if result := judge(diff, offset); result != 100 {
+		t.Errorf("expected %dms offset to be a 100 with custom windows, got %d", offset, result)
+	}
+
+	diff.SetMods(HardRock)
+	diff.SetCustomSpeed(1.2)
+	diff.SetODCustom(10)
+
+	if diff.Hit300 != 20 || diff.Hit100 != 60 || diff.Hit50 != 100 {
+		t.Errorf("custom windows lost on recalculation, got 300: %dms, 100: %dms, 50: %dms", diff.Hit300, diff.Hit100, diff.Hit50)
+	}
+
+	if result := judge(diff, offset); result != 100 {
+		t.Errorf("expected %dms offset to be a 100 after recalculation, got %d", offset, result)
+	}
+}
+
+func TestSetHitWindowsInvalid(t *testing.T) {
+	diff := NewDifficulty(5, 4, 8, 9)
+
+	for _, w := range [][3]int64{{0, 60, 100}, {60, 20, 100}, {20, 60, 500}} {
+		if err := diff.SetHitWindows(w[0], w[1], w[2]); err == nil {
+			t.Errorf("expected error for windows %v", w)
+		}
+	}
+
+	if diff.Hit300 != 32 || diff.Hit100 != 76 || diff.Hit50 != 120 {
+		t.Errorf("invalid windows changed OD8 windows to 300: %dms, 100: %dms, 50: %dms", diff.Hit300, diff.Hit100, diff.Hit50)
+	}
+}
@@ -1,5 +1,11 @@
 package difficulty
 
+import (
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
 type Modifier int64
 
 const (
@@ -62,6 +68,15 @@ func GetDiffMaskedMods(mods Modifier) Modifier {
 	return base
 }
 
+// SplitDiffMods splits active mods into ones that change difficulty attributes and ones that don't.
+// Mods combinations with the same diffMods share difficulty calculations
+func SplitDiffMods(mods Modifier) (diffMods, otherMods Modifier) {
+	diffMods = mods & (GetDiffMaskedMods(mods) | Nightcore | Daycore)
+	otherMods = mods & ^diffMods
+
+	return
+}
+
 var modsString = [...]string{
 	"NF",
 	"EZ",
@@ -182,7 +197,8 @@ func (mods Modifier) GetScoreMultiplier() float64 {
 	return multiplier
 }
 
-func (mods Modifier) String() (s string) {
+// Split decomposes the bitmask into individual mods. Implied mods are skipped, i.e. NC doesn't give DT, PF doesn't give SD.
+func (mods Modifier) Split() (s []Modifier) {
 	if mods.Active(Nightcore) {
 		mods &= ^DoubleTime
 	}
@@ -196,55 +212,85 @@ func (mods Modifier) String() (s string) {
 	}
 
 	for i := 0; i < len(modsString); i++ {
-		activated := mods&1 == 1
-		if activated {
-			s += modsString[i]
+		if mod := Modifier(1 << i); mods&mod > 0 {
+			s = append(s, mod)
 		}
+	}
+
+	return
+}
 
-		mods >>= 1
+func (mods Modifier) String() (s string) {
+	for _, mod := range mods.Split() {
+		s += modsString[bits.TrailingZeros64(uint64(mod))]
 	}
 
 	return
 }
 
 func (mods Modifier) StringFull() (s []string) {
-	if mods.Active(Nightcore) {
-		mods &= ^DoubleTime
+	for _, mod := range mods.Split() {
+		s = append(s, modsStringFull[bits.TrailingZeros64(uint64(mod))])
 	}
 
-	if mods.Active(Daycore) {
-		mods &= ^HalfTime
-	}
+	return
+}
 
-	if mods.Active(Perfect) {
-		mods &= ^SuddenDeath
+func ParseMods(mods string) (m Modifier) {
+	modsSl := make([]string, len(mods)/2)
+	for n, modPart := range mods {
+		modsSl[n/2] += string(modPart)
 	}
 
-	for i := 0; i < len(modsString); i++ {
-		activated := mods&1 == 1
-		if activated {
-			s = append(s, modsStringFull[i])
+	for _, mod := range modsSl {
+		for index, availableMod := range modsString {
+			if availableMod == mod {
+				m |= 1 << uint(index)
+				break
+			}
 		}
+	}
+
+	if m.Active(Nightcore) {
+		m |= DoubleTime
+	}
 
-		mods >>= 1
+	if m.Active(Perfect) {
+		m |= SuddenDeath
+	}
+
+	if m.Active(Daycore) {
+		m |= HalfTime
 	}
 
 	return
 }
 
-func ParseMods(mods string) (m Modifier) {
-	modsSl := make([]string, len(mods)/2)
-	for n, modPart := range mods {
-		modsSl[n/2] += string(modPart)
+// ParseModsStrict works like ParseMods but reports malformed input, unknown acronyms and incompatible combinations instead of silently ignoring them
+func ParseModsStrict(mods string) (m Modifier, err error) {
+	mods = strings.ToUpper(strings.TrimSpace(mods))
+
+	if len(mods)%2 != 0 {
+		return None, fmt.Errorf("invalid mod string length: %s", mods)
 	}
 
-	for _, mod := range modsSl {
+	for i := 0; i < len(mods); i += 2 {
+		mod := mods[i : i+2]
+
+		found := false
+
 		for index, availableMod := range modsString {
 			if availableMod == mod {
 				m |= 1 << uint(index)
+				found = true
+
 				break
 			}
 		}
+
+		if !found {
+			return None, fmt.Errorf("unknown mod: %s", mod)
+		}
 	}
 
 	if m.Active(Nightcore) {
@@ -259,6 +305,10 @@ func ParseMods(mods string) (m Modifier) {
 		m |= HalfTime
 	}
 
+	if !m.Compatible() {
+		return None, fmt.Errorf("incompatible mods: %s", m.String())
+	}
+
 	return
 }
 
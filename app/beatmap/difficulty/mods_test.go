@@ -0,0 +1,39 @@
+package difficulty
+
+import "testing"
+
+func TestParseModsStrict(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Modifier
+		wantErr bool
+	}{
+		{"", None, false},
+		{"HD", Hidden, false},
+		{"hdhr", Hidden | HardRock, false},
+		{" HDDT ", Hidden | DoubleTime, false},
+		{"NC", Nightcore | DoubleTime, false},
+		{"PF", Perfect | SuddenDeath, false},
+		{"H", None, true},
+		{"HDX", None, true},
+		{"XX", None, true},
+		{"HDZZ", None, true},
+		{"EZHR", None, true},
+		{"DTHT", None, true},
+		{"NFSD", None, true},
+		{"RXAP", None, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseModsStrict(tt.input)
+
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseModsStrict(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+
+		if got != tt.want {
+			t.Errorf("ParseModsStrict(%q) = %s, want %s", tt.input, got.String(), tt.want.String())
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/wieku/danser-go/framework/math/animation"
 	"github.com/wieku/danser-go/framework/math/animation/easing"
 	color2 "github.com/wieku/danser-go/framework/math/color"
+	"github.com/wieku/danser-go/framework/math/mutils"
 	"github.com/wieku/danser-go/framework/math/vector"
 	"math"
 	"strconv"
@@ -179,8 +180,10 @@ func (circle *Circle) SetDifficulty(diff *difficulty.Difficulty) {
 	for _, t := range circles {
 		if diff.CheckModActive(difficulty.Hidden) {
 			if !circle.SliderPoint || circle.SliderPointStart || circle.firstEndCircle {
+				fadeOut := mutils.ClampF(settings.Objects.HiddenFadeFraction, 0.5, 1.0)
+
 				t.AddTransform(animation.NewSingleTransform(animation.Fade, easing.Linear, startTime, startTime+diff.Preempt*0.4, 0.0, 1.0))
-				t.AddTransform(animation.NewSingleTransform(animation.Fade, easing.Linear, startTime+diff.Preempt*0.4, startTime+diff.Preempt*0.7, 1.0, 0.0))
+				t.AddTransform(animation.NewSingleTransform(animation.Fade, easing.Linear, startTime+diff.Preempt*0.4, startTime+diff.Preempt*fadeOut, 1.0, 0.0))
 			}
 		} else {
 			t.AddTransform(animation.NewSingleTransform(animation.Fade, easing.Linear, startTime, startTime+diff.TimeFadeIn, 0.0, 1.0))
@@ -134,6 +134,38 @@ func (tim *Timings) GetOriginalPointAt(time float64) TimingPoint {
 	return tim.originalPoints[mutils.Max(0, index-1)]
 }
 
+// GetMainBeatLength returns the beat length of the uninherited timing point that lasts the longest until endTime
+func (tim *Timings) GetMainBeatLength(endTime float64) float64 {
+	if len(tim.originalPoints) == 0 {
+		return tim.defaultTimingPoint.beatLengthBase
+	}
+
+	durations := make(map[float64]float64)
+
+	mainBeatLength := tim.originalPoints[0].beatLengthBase
+	maxDuration := -1.0
+
+	for i, point := range tim.originalPoints {
+		if point.Time > endTime {
+			break
+		}
+
+		end := endTime
+		if i < len(tim.originalPoints)-1 {
+			end = math.Min(end, tim.originalPoints[i+1].Time)
+		}
+
+		durations[point.beatLengthBase] += end - math.Max(point.Time, 0)
+
+		if d := durations[point.beatLengthBase]; d > maxDuration {
+			maxDuration = d
+			mainBeatLength = point.beatLengthBase
+		}
+	}
+
+	return mainBeatLength
+}
+
 func (tim *Timings) GetScoringDistance() float64 {
 	return (100 * tim.SliderMult) / tim.TickRate
 }
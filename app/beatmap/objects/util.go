@@ -2,10 +2,13 @@ package objects
 
 import (
 	"github.com/wieku/danser-go/app/settings"
+	"log"
 	"strconv"
 )
 
-func CreateObject(data []string) IHitObject {
+// CreateObject parses a hit object. Zero-length sliders are handled according to Objects.MalformedSliders unless
+// keepMalformed is set, difficulty calculation has to see them the same way osu! does.
+func CreateObject(data []string, keepMalformed bool) IHitObject {
 	objTypeI, _ := strconv.Atoi(data[3])
 	objType := Type(objTypeI)
 
@@ -17,6 +20,19 @@ func CreateObject(data []string) IHitObject {
 		}
 	} else if (objType & SLIDER) > 0 {
 		if sl := NewSlider(data); sl != nil {
+			if keepMalformed || (sl.pixelLength > 0 && sl.multiCurve.GetLength() > 0) {
+				return sl
+			}
+
+			switch settings.Objects.MalformedSliders {
+			case "Circles":
+				log.Printf("Zero-length slider at %.0fms, converting to a circle", sl.StartTime)
+				return NewCircle(sliderToCircleData(data))
+			case "Skip":
+				log.Printf("Zero-length slider at %.0fms, skipping", sl.StartTime)
+				return nil
+			}
+
 			return sl
 		}
 	}
@@ -24,6 +40,19 @@ func CreateObject(data []string) IHitObject {
 	return nil
 }
 
+// sliderToCircleData rewrites slider's definition to a circle with the same position, time, combo and hitsounds
+func sliderToCircleData(data []string) []string {
+	objType, _ := strconv.Atoi(data[3])
+
+	circleData := []string{data[0], data[1], data[2], strconv.Itoa(int((Type(objType) &^ SLIDER) | CIRCLE)), data[4]}
+
+	if len(data) > 10 {
+		circleData = append(circleData, data[10])
+	}
+
+	return circleData
+}
+
 type Type int
 
 const (
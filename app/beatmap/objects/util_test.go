@@ -0,0 +1,50 @@
+package objects
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wieku/danser-go/app/settings"
+)
+
+func TestCreateObjectZeroLengthSlider(t *testing.T) {
+	mode := settings.Objects.MalformedSliders
+	t.Cleanup(func() { settings.Objects.MalformedSliders = mode })
+
+	data := strings.Split("256,192,1000,6,0,L|256:192,1,0", ",")
+
+	tests := []struct {
+		mode          string
+		keepMalformed bool
+		want          string // slider, circle or nil
+	}{
+		{"Keep", false, "slider"},
+		{"Circles", false, "circle"},
+		{"Skip", false, "nil"},
+		{"Circles", true, "slider"},
+		{"Skip", true, "slider"},
+	}
+
+	for _, tt := range tests {
+		settings.Objects.MalformedSliders = tt.mode
+
+		obj := CreateObject(append([]string(nil), data...), tt.keepMalformed)
+
+		got := "nil"
+
+		switch o := obj.(type) {
+		case *Slider:
+			got = "slider"
+		case *Circle:
+			got = "circle"
+
+			if o.GetStartTime() != 1000 || o.GetStartPosition().X != 256 || o.GetStartPosition().Y != 192 || !o.IsNewCombo() {
+				t.Errorf("%s: circle doesn't match the slider: %.0fms at %v", tt.mode, o.GetStartTime(), o.GetStartPosition())
+			}
+		}
+
+		if got != tt.want {
+			t.Errorf("mode %s, keepMalformed %t: expected %s, got %s", tt.mode, tt.keepMalformed, tt.want, got)
+		}
+	}
+}
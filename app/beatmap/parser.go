@@ -7,6 +7,7 @@ import (
 	"github.com/wieku/danser-go/app/skin"
 	"github.com/wieku/danser-go/framework/files"
 	"github.com/wieku/danser-go/framework/math/mutils"
+	"log"
 	"math"
 	"os"
 	"path/filepath"
@@ -106,14 +107,35 @@ func parseEvents(line []string, beatMap *BeatMap) {
 	}
 }
 
-func parseHitObjects(line []string, beatMap *BeatMap) {
-	obj := objects.CreateObject(line)
+func parseHitObjects(line []string, beatMap *BeatMap, diffCalcOnly bool) {
+	obj := objects.CreateObject(line, diffCalcOnly)
 
 	if obj != nil {
 		beatMap.HitObjects = append(beatMap.HitObjects, obj)
 	}
 }
 
+// removeDuplicateSliders removes sliders that start at the same time and position as the previous slider
+func removeDuplicateSliders(beatMap *BeatMap) {
+	filtered := beatMap.HitObjects[:0]
+
+	var previous objects.IHitObject
+
+	for _, o := range beatMap.HitObjects {
+		if _, ok := o.(*objects.Slider); ok && previous != nil {
+			if _, ok2 := previous.(*objects.Slider); ok2 && previous.GetStartTime() == o.GetStartTime() && previous.GetStartPosition() == o.GetStartPosition() {
+				log.Printf("Duplicate slider at %.0fms, skipping", o.GetStartTime())
+				continue
+			}
+		}
+
+		filtered = append(filtered, o)
+		previous = o
+	}
+
+	beatMap.HitObjects = filtered
+}
+
 func tokenize(line, delimiter string) []string {
 	return tokenizeN(line, delimiter, -1)
 }
@@ -337,7 +359,7 @@ func ParseObjects(beatMap *BeatMap, diffCalcOnly, parseColors bool) {
 			}
 		case "HitObjects":
 			if arr := tokenize(line, ","); arr != nil {
-				parseHitObjects(arr, beatMap)
+				parseHitObjects(arr, beatMap, diffCalcOnly)
 			}
 		}
 	}
@@ -346,6 +368,10 @@ func ParseObjects(beatMap *BeatMap, diffCalcOnly, parseColors bool) {
 		return beatMap.HitObjects[i].GetStartTime() < beatMap.HitObjects[j].GetStartTime()
 	})
 
+	if !diffCalcOnly && settings.Objects.MalformedSliders != "Keep" {
+		removeDuplicateSliders(beatMap)
+	}
+
 	if parseColors {
 		skin.FinishBeatmapColors()
 	}
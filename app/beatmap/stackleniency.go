@@ -33,11 +33,18 @@ func calculateStackLeniency(b *BeatMap, diffCalcOnly bool) {
 	diffEZ.SetMods(difficulty.Easy)
 	diffHR.SetMods(difficulty.HardRock)
 
-	processStacking(b.HitObjects, b.Version, diffNM, b.StackLeniency)
+	leniency := b.StackLeniency
+
+	// Difficulty calculation always uses beatmap's value
+	if !diffCalcOnly && settings.Objects.StackLeniency >= 0 {
+		leniency = settings.Objects.StackLeniency
+	}
+
+	processStacking(b.HitObjects, b.Version, diffNM, leniency)
 
 	if !diffCalcOnly {
-		processStacking(b.HitObjects, b.Version, diffEZ, b.StackLeniency)
-		processStacking(b.HitObjects, b.Version, diffHR, b.StackLeniency)
+		processStacking(b.HitObjects, b.Version, diffEZ, leniency)
+		processStacking(b.HitObjects, b.Version, diffHR, leniency)
 	}
 
 	for _, v := range b.HitObjects {
@@ -8,6 +8,7 @@ import (
 	"github.com/wieku/danser-go/app/dance/spinners"
 	"github.com/wieku/danser-go/app/graphics"
 	"github.com/wieku/danser-go/app/settings"
+	"log"
 	"sort"
 	"strings"
 )
@@ -19,6 +20,9 @@ type Controller interface {
 	GetCursors() []*graphics.Cursor
 }
 
+// newCursor creates cursors for GenericController, tests replace it to run without graphics
+var newCursor = graphics.NewCursor
+
 type GenericController struct {
 	bMap       *beatmap.BeatMap
 	cursors    []*graphics.Cursor
@@ -41,11 +45,38 @@ func (controller *GenericController) InitCursors() {
 
 	// Mover initialization
 	for i := range controller.cursors {
-		controller.cursors[i] = graphics.NewCursor()
+		controller.cursors[i] = newCursor()
 
 		mover := "flower"
+		preset := ""
+
 		if len(settings.CursorDance.Movers) > 0 {
-			mover = strings.ToLower(settings.CursorDance.Movers[i%len(settings.CursorDance.Movers)].Mover)
+			config := settings.CursorDance.Movers[i%len(settings.CursorDance.Movers)]
+
+			mover = strings.ToLower(config.Mover)
+			preset = config.Preset
+		}
+
+		if preset != "" {
+			pMover, ms, err := settings.CursorDance.NewPresetMoverSettings(preset)
+			if err == nil {
+				moverCtor, mName := movers.GetMoverCtorByName(pMover)
+
+				presetCtor := func() movers.MultiPointMover {
+					m := moverCtor()
+					m.SetSettings(ms)
+
+					return m
+				}
+
+				controller.schedulers[i] = schedulers.NewGenericScheduler(presetCtor, i, 0)
+
+				log.Printf("Cursor %d uses \"%s\" preset (%s)", i, preset, mName)
+
+				continue
+			}
+
+			log.Println("Failed to apply mover preset:", err)
 		}
 
 		moverCtor, mName := movers.GetMoverCtorByName(mover)
@@ -0,0 +1,69 @@
+package dance
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap"
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+	"github.com/wieku/danser-go/app/graphics"
+	"github.com/wieku/danser-go/app/rulesets/osu"
+	"github.com/wieku/danser-go/app/settings"
+)
+
+func TestAutoplayRunToEndSS(t *testing.T) {
+	dir, err := filepath.Abs(filepath.Join("..", "rulesets", "osu", "testdata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	songsDir, ripples, smoke := settings.General.OsuSongsDir, settings.Cursor.CursorRipples, settings.Cursor.SmokeEnabled
+
+	settings.General.OsuSongsDir = dir
+	settings.Cursor.CursorRipples = false
+	settings.Cursor.SmokeEnabled = false
+	newCursor = graphics.NewHeadlessCursor
+
+	t.Cleanup(func() {
+		settings.General.OsuSongsDir, settings.Cursor.CursorRipples, settings.Cursor.SmokeEnabled = songsDir, ripples, smoke
+		newCursor = graphics.NewCursor
+	})
+
+	beatMap := beatmap.NewBeatMap()
+	beatMap.Dir = "maps"
+	beatMap.File = "circles.osu"
+
+	if err = beatmap.ParseBeatMap(beatMap); err != nil {
+		t.Fatal(err)
+	}
+
+	beatmap.ParseObjects(beatMap, false, false)
+
+	controller := NewGenericController()
+	controller.SetBeatMap(beatMap)
+	controller.InitCursors()
+
+	cursor := controller.GetCursors()[0]
+	cursor.IsPlayer = true
+	cursor.IsAutoplay = true
+
+	ruleset := osu.NewOsuRuleset(beatMap, []*graphics.Cursor{cursor}, []difficulty.Modifier{difficulty.None})
+
+	ruleset.SetStepListener(func(time int64) {
+		controller.Update(float64(time), 1)
+	})
+
+	ruleset.RunToEnd(1)
+
+	score := ruleset.GetScore(cursor)
+
+	objectCount := uint(len(beatMap.HitObjects))
+
+	if score.Count300 != objectCount || score.Grade != osu.SS {
+		t.Errorf("expected SS with %d 300s, got %s with 300: %d, 100: %d, 50: %d, miss: %d", objectCount, score.Grade.String(), score.Count300, score.Count100, score.Count50, score.CountMiss)
+	}
+
+	if score.Combo != objectCount {
+		t.Errorf("expected %dx combo, got %dx", objectCount, score.Combo)
+	}
+}
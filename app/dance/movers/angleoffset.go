@@ -3,7 +3,6 @@ package movers
 import (
 	"github.com/wieku/danser-go/app/beatmap/difficulty"
 	"github.com/wieku/danser-go/app/beatmap/objects"
-	"github.com/wieku/danser-go/app/settings"
 	"github.com/wieku/danser-go/framework/math/curves"
 	"github.com/wieku/danser-go/framework/math/math32"
 	"github.com/wieku/danser-go/framework/math/mutils"
@@ -34,7 +33,7 @@ func (mover *AngleOffsetMover) Reset(diff *difficulty.Difficulty, id int) {
 }
 
 func (mover *AngleOffsetMover) SetObjects(objs []objects.IHitObject) int {
-	config := settings.CursorDance.MoverSettings.Flower[mover.id%len(settings.CursorDance.MoverSettings.Flower)]
+	config := mover.moverSettings().Flower[mover.id%len(mover.moverSettings().Flower)]
 
 	start, end := objs[0], objs[1]
 
@@ -3,7 +3,6 @@ package movers
 import (
 	"github.com/wieku/danser-go/app/beatmap/difficulty"
 	"github.com/wieku/danser-go/app/beatmap/objects"
-	"github.com/wieku/danser-go/app/settings"
 	"github.com/wieku/danser-go/framework/math/curves"
 	"github.com/wieku/danser-go/framework/math/math32"
 	"github.com/wieku/danser-go/framework/math/mutils"
@@ -33,7 +32,7 @@ func (mover *BezierMover) Reset(diff *difficulty.Difficulty, id int) {
 }
 
 func (mover *BezierMover) SetObjects(objs []objects.IHitObject) int {
-	config := settings.CursorDance.MoverSettings.Bezier[mover.id%len(settings.CursorDance.MoverSettings.Bezier)]
+	config := mover.moverSettings().Bezier[mover.id%len(mover.moverSettings().Bezier)]
 
 	start, end := objs[0], objs[1]
 
@@ -3,7 +3,6 @@ package movers
 import (
 	"github.com/wieku/danser-go/app/beatmap/difficulty"
 	"github.com/wieku/danser-go/app/beatmap/objects"
-	"github.com/wieku/danser-go/app/settings"
 	"github.com/wieku/danser-go/framework/math/animation/easing"
 	"github.com/wieku/danser-go/framework/math/vector"
 	"math/rand"
@@ -33,7 +32,7 @@ func (mover *ExGonMover) Reset(diff *difficulty.Difficulty, id int) {
 }
 
 func (mover *ExGonMover) SetObjects(objs []objects.IHitObject) int {
-	config := settings.CursorDance.MoverSettings.ExGon[mover.id%len(settings.CursorDance.MoverSettings.ExGon)]
+	config := mover.moverSettings().ExGon[mover.id%len(mover.moverSettings().ExGon)]
 	mover.delay = float64(config.Delay)
 
 	if !mover.wasFirst {
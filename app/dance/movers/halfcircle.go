@@ -3,7 +3,6 @@ package movers
 import (
 	"github.com/wieku/danser-go/app/beatmap/difficulty"
 	"github.com/wieku/danser-go/app/beatmap/objects"
-	"github.com/wieku/danser-go/app/settings"
 	"github.com/wieku/danser-go/framework/math/curves"
 	"github.com/wieku/danser-go/framework/math/mutils"
 	"github.com/wieku/danser-go/framework/math/vector"
@@ -29,7 +28,7 @@ func (mover *HalfCircleMover) Reset(diff *difficulty.Difficulty, id int) {
 }
 
 func (mover *HalfCircleMover) SetObjects(objs []objects.IHitObject) int {
-	config := settings.CursorDance.MoverSettings.HalfCircle[mover.id%len(settings.CursorDance.MoverSettings.HalfCircle)]
+	config := mover.moverSettings().HalfCircle[mover.id%len(mover.moverSettings().HalfCircle)]
 
 	start, end := objs[0], objs[1]
 
@@ -2,7 +2,6 @@ package movers
 
 import (
 	"github.com/wieku/danser-go/app/beatmap/objects"
-	"github.com/wieku/danser-go/app/settings"
 	"github.com/wieku/danser-go/framework/math/animation/easing"
 	"github.com/wieku/danser-go/framework/math/curves"
 	"github.com/wieku/danser-go/framework/math/mutils"
@@ -42,7 +41,7 @@ func (mover *LinearMover) SetObjects(objs []objects.IHitObject) int {
 	if mover.simple {
 		mover.startTime = math.Max(mover.startTime, mover.endTime-(mover.diff.Preempt-100*mover.diff.Speed))
 	} else {
-		config := settings.CursorDance.MoverSettings.Linear[mover.id%len(settings.CursorDance.MoverSettings.Linear)]
+		config := mover.moverSettings().Linear[mover.id%len(mover.moverSettings().Linear)]
 
 		if config.WaitForPreempt {
 			mover.startTime = math.Max(mover.startTime, mover.endTime-(mover.diff.Preempt-config.ReactionTime*mover.diff.Speed))
@@ -58,7 +57,7 @@ func (mover *LinearMover) Update(time float64) vector.Vector2f {
 }
 
 func (mover *LinearMover) GetObjectsPosition(time float64, object objects.IHitObject) vector.Vector2f {
-	config := settings.CursorDance.MoverSettings.Linear[mover.id%len(settings.CursorDance.MoverSettings.Linear)]
+	config := mover.moverSettings().Linear[mover.id%len(mover.moverSettings().Linear)]
 
 	if !config.ChoppyLongObjects || mover.simple || object.GetType() == objects.CIRCLE {
 		return mover.basicMover.GetObjectsPosition(time, object)
@@ -3,7 +3,6 @@ package movers
 import (
 	"github.com/wieku/danser-go/app/beatmap/difficulty"
 	"github.com/wieku/danser-go/app/beatmap/objects"
-	"github.com/wieku/danser-go/app/settings"
 	"github.com/wieku/danser-go/framework/math/curves"
 	"github.com/wieku/danser-go/framework/math/math32"
 	"github.com/wieku/danser-go/framework/math/mutils"
@@ -16,7 +15,7 @@ import (
 type MomentumMover struct {
 	*basicMover
 
-	curve *curves.Bezier
+	curve curves.Curve
 
 	last      vector.Vector2f
 	first     bool
@@ -58,7 +57,7 @@ func anorm2(a float32) float32 {
 }
 
 func (mover *MomentumMover) SetObjects(objs []objects.IHitObject) int {
-	ms := settings.CursorDance.MoverSettings.Momentum[mover.id%len(settings.CursorDance.MoverSettings.Momentum)]
+	ms := mover.moverSettings().Momentum[mover.id%len(mover.moverSettings().Momentum)]
 
 	i := 0
 
@@ -126,6 +125,14 @@ func (mover *MomentumMover) SetObjects(objs []objects.IHitObject) int {
 		a1 = startPos.AngleRV(mover.last)
 	}
 
+	if ms.GroupLookahead > 0 && stream && !mover.first {
+		if n := mover.streamGroupLength(objs, ms.GroupLookahead); n > 2 {
+			mover.setStreamGroup(objs[:n], a1, float32(ms.StreamMult))
+
+			return n
+		}
+	}
+
 	mult := ms.DistanceMultOut
 
 	ac := a2 - endPos.AngleRV(startPos)
@@ -170,6 +177,13 @@ func (mover *MomentumMover) SetObjects(objs []objects.IHitObject) int {
 	p1 := vector.NewVec2fRad(a1, dst*float32(mult)).Add(startPos)
 	p2 := vector.NewVec2fRad(a2, dst*float32(mult)).Add(endPos)
 
+	// Push the incoming control point past the target so the cursor overshoots long jumps and settles back,
+	// the further the jump the bigger the overshoot, the more time there is the less of it is left
+	if ms.Overshoot > 0 && float64(dst) > ms.OvershootThreshold {
+		amount := (float64(dst) - ms.OvershootThreshold) * ms.Overshoot * math.Exp(-ms.OvershootDecay*duration/1000)
+		p2 = p2.Add(endPos.Sub(startPos).Nor().Scl(float32(amount)))
+	}
+
 	if !same(mover.diff.Mods, start, end, ms.SkipStackAngles) {
 		mover.last = p2
 		mover.curve = curves.NewBezierNA([]vector.Vector2f{startPos, p1, p2, endPos})
@@ -184,6 +198,83 @@ func (mover *MomentumMover) SetObjects(objs []objects.IHitObject) int {
 	return 2
 }
 
+// streamGroupLength returns how many objects from the beginning of objs form an evenly spaced circle stream, at most lookahead+2
+func (mover *MomentumMover) streamGroupLength(objs []objects.IHitObject, lookahead int) int {
+	limit := mutils.Min(len(objs), lookahead+2)
+
+	prevPos := objs[0].GetStackedEndPositionMod(mover.diff.Mods)
+	prevTime := objs[0].GetEndTime()
+
+	gap := objs[1].GetStartTime() - prevTime
+
+	if gap <= 0 {
+		return 1
+	}
+
+	n := 1
+
+	for ; n < limit; n++ {
+		if _, ok := objs[n].(*objects.Circle); !ok {
+			break
+		}
+
+		pos := objs[n].GetStackedStartPositionMod(mover.diff.Mods)
+
+		if dst := prevPos.DstSq(pos); dst < 25 || dst > 10000 {
+			break
+		}
+
+		if math.Abs(objs[n].GetStartTime()-prevTime-gap) > gap*0.1 {
+			break
+		}
+
+		prevPos = pos
+		prevTime = objs[n].GetStartTime()
+	}
+
+	return n
+}
+
+// setStreamGroup creates a single spline going through all objects, entering at angle a1 so it continues the previous curve
+func (mover *MomentumMover) setStreamGroup(objs []objects.IHitObject, a1, mult float32) {
+	startPos := objs[0].GetStackedEndPositionMod(mover.diff.Mods)
+	secondPos := objs[1].GetStackedStartPositionMod(mover.diff.Mods)
+
+	endPos := objs[len(objs)-1].GetStackedStartPositionMod(mover.diff.Mods)
+	beforeEndPos := objs[len(objs)-2].GetStackedStartPositionMod(mover.diff.Mods)
+
+	points := []vector.Vector2f{startPos, vector.NewVec2fRad(a1, startPos.Dst(secondPos)*mult).Add(startPos)}
+	timeDiff := make([]float32, 0, len(objs)-1)
+
+	for i := 1; i < len(objs); i++ {
+		if i < len(objs)-1 {
+			points = append(points, objs[i].GetStackedStartPositionMod(mover.diff.Mods))
+		}
+
+		prevTime := objs[i-1].GetStartTime()
+		if i == 1 {
+			prevTime = objs[0].GetEndTime()
+		}
+
+		timeDiff = append(timeDiff, float32(objs[i].GetStartTime()-prevTime))
+	}
+
+	points = append(points, endPos.Lerp(beforeEndPos, 0.333), endPos)
+
+	beziers := curves.SolveBSpline(points)
+	beziersC := make([]curves.Curve, len(beziers))
+
+	for i, b := range beziers {
+		beziersC[i] = b
+	}
+
+	mover.curve = curves.NewSplineW(beziersC, timeDiff)
+	mover.last = beziers[len(beziers)-1].Points[2]
+
+	mover.startTime = objs[0].GetEndTime()
+	mover.endTime = objs[len(objs)-1].GetStartTime()
+}
+
 func (mover *MomentumMover) Update(time float64) vector.Vector2f {
 	t := mutils.ClampF((time-mover.startTime)/(mover.endTime-mover.startTime), 0, 1)
 	return mover.curve.PointAt(float32(t))
@@ -3,6 +3,7 @@ package movers
 import (
 	"github.com/wieku/danser-go/app/beatmap/difficulty"
 	"github.com/wieku/danser-go/app/beatmap/objects"
+	"github.com/wieku/danser-go/app/settings"
 	"github.com/wieku/danser-go/framework/math/vector"
 	"strings"
 )
@@ -11,6 +12,7 @@ const sixtyTime = 1000.0 / 60
 
 type MultiPointMover interface {
 	Reset(diff *difficulty.Difficulty, id int)
+	SetSettings(ms *settings.MoverSettings)
 	SetObjects(objs []objects.IHitObject) int
 	Update(time float64) vector.Vector2f
 	GetObjectsStartTime(object objects.IHitObject) float64
@@ -28,6 +30,9 @@ type basicMover struct {
 
 	id int
 
+	// settings replace CursorDance.MoverSettings if set, e.g. ones created from a preset
+	settings *settings.MoverSettings
+
 	diff *difficulty.Difficulty
 }
 
@@ -36,6 +41,18 @@ func (mover *basicMover) Reset(diff *difficulty.Difficulty, id int) {
 	mover.id = id
 }
 
+func (mover *basicMover) SetSettings(ms *settings.MoverSettings) {
+	mover.settings = ms
+}
+
+func (mover *basicMover) moverSettings() *settings.MoverSettings {
+	if mover.settings != nil {
+		return mover.settings
+	}
+
+	return settings.CursorDance.MoverSettings
+}
+
 func (mover *basicMover) GetObjectsStartTime(object objects.IHitObject) float64 {
 	return object.GetStartTime()
 }
@@ -2,7 +2,6 @@ package movers
 
 import (
 	"github.com/wieku/danser-go/app/beatmap/objects"
-	"github.com/wieku/danser-go/app/settings"
 	"github.com/wieku/danser-go/framework/math/animation/easing"
 	"github.com/wieku/danser-go/framework/math/curves"
 	"github.com/wieku/danser-go/framework/math/mutils"
@@ -84,7 +83,7 @@ func (mover *PippiMover) GetObjectsPosition(time float64, object objects.IHitObj
 }
 
 func (mover *PippiMover) modifyPos(time float64, spinner bool, pos vector.Vector2f) vector.Vector2f {
-	config := settings.CursorDance.MoverSettings.Pippi[mover.id%len(settings.CursorDance.MoverSettings.Pippi)]
+	config := mover.moverSettings().Pippi[mover.id%len(mover.moverSettings().Pippi)]
 
 	rad := math.Mod(time/1000*config.RotationSpeed, 1) * 2 * math.Pi
 
@@ -2,7 +2,6 @@ package movers
 
 import (
 	"github.com/wieku/danser-go/app/beatmap/objects"
-	"github.com/wieku/danser-go/app/settings"
 	"github.com/wieku/danser-go/framework/math/curves"
 	"github.com/wieku/danser-go/framework/math/math32"
 	"github.com/wieku/danser-go/framework/math/mutils"
@@ -27,7 +26,7 @@ func NewSplineMover() MultiPointMover {
 }
 
 func (mover *SplineMover) SetObjects(objs []objects.IHitObject) int {
-	config := settings.CursorDance.MoverSettings.Spline[mover.id%len(settings.CursorDance.MoverSettings.Spline)]
+	config := mover.moverSettings().Spline[mover.id%len(mover.moverSettings().Spline)]
 
 	points := make([]vector.Vector2f, 0)
 	timing := make([]float64, 0)
@@ -0,0 +1,94 @@
+package dance
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/wieku/danser-go/app/beatmap/objects"
+	"github.com/wieku/danser-go/app/dance/movers"
+	"github.com/wieku/danser-go/app/dance/schedulers"
+	"github.com/wieku/danser-go/app/dance/spinners"
+	"github.com/wieku/danser-go/app/graphics"
+	"html"
+	"os"
+)
+
+const pathSampleInterval = 5.0
+
+// ExportMoverPath runs given mover over the whole map without rendering and saves cursor's trajectory as SVG with object markers
+func (controller *GenericController) ExportMoverPath(path string, moverName string) error {
+	if controller.bMap == nil || len(controller.bMap.HitObjects) == 0 {
+		return errors.New("beatmap has no objects")
+	}
+
+	moverCtor, mName := movers.GetMoverCtorByName(moverName)
+
+	diff := controller.bMap.Diff
+
+	queue := controller.bMap.GetObjectsCopy()
+
+	for i := 0; i < len(queue); i++ {
+		if s, ok := queue[i].(*objects.Slider); ok && s.IsRetarded() {
+			queue = schedulers.PreprocessQueue(i, queue, true)
+		}
+	}
+
+	cursor := graphics.NewHeadlessCursor()
+
+	scheduler := schedulers.NewGenericScheduler(moverCtor, 0, 0)
+	scheduler.Init(queue, diff, cursor, spinners.GetMoverCtorByName("circle"), false)
+
+	startTime := controller.bMap.HitObjects[0].GetStartTime() - diff.Preempt
+	endTime := controller.bMap.HitObjects[len(controller.bMap.HitObjects)-1].GetEndTime()
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create SVG file: %w", err)
+	}
+
+	defer file.Close()
+
+	w := bufio.NewWriter(file)
+
+	_, _ = fmt.Fprintf(w, "<svg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"0 0 512 384\" width=\"1024\" height=\"768\">\n")
+	_, _ = fmt.Fprintf(w, "<title>%s - %s [%s] (%s)</title>\n", html.EscapeString(controller.bMap.Artist), html.EscapeString(controller.bMap.Name), html.EscapeString(controller.bMap.Difficulty), mName)
+	_, _ = fmt.Fprintf(w, "<rect width=\"512\" height=\"384\" fill=\"black\"/>\n")
+
+	for _, o := range controller.bMap.HitObjects {
+		pos := o.GetStackedStartPositionMod(diff.Mods)
+
+		switch o.(type) {
+		case *objects.Spinner:
+			_, _ = fmt.Fprintf(w, "<circle cx=\"%.2f\" cy=\"%.2f\" r=\"%.2f\" fill=\"none\" stroke=\"#ffd966\" stroke-opacity=\"0.5\"/>\n", pos.X, pos.Y, diff.CircleRadius*2)
+		case *objects.Slider:
+			end := o.GetStackedEndPositionMod(diff.Mods)
+
+			_, _ = fmt.Fprintf(w, "<line x1=\"%.2f\" y1=\"%.2f\" x2=\"%.2f\" y2=\"%.2f\" stroke=\"#66aaff\" stroke-opacity=\"0.3\"/>\n", pos.X, pos.Y, end.X, end.Y)
+			_, _ = fmt.Fprintf(w, "<circle cx=\"%.2f\" cy=\"%.2f\" r=\"%.2f\" fill=\"none\" stroke=\"#66aaff\" stroke-opacity=\"0.5\"/>\n", pos.X, pos.Y, diff.CircleRadius)
+		default:
+			_, _ = fmt.Fprintf(w, "<circle cx=\"%.2f\" cy=\"%.2f\" r=\"%.2f\" fill=\"none\" stroke=\"#ff6680\" stroke-opacity=\"0.5\"/>\n", pos.X, pos.Y, diff.CircleRadius)
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "<path fill=\"none\" stroke=\"white\" stroke-width=\"0.75\" d=\"")
+
+	for time, i := startTime, 0; time <= endTime; time, i = time+pathSampleInterval, i+1 {
+		scheduler.Update(time)
+		cursor.Update(pathSampleInterval)
+
+		cmd := "L"
+		if i == 0 {
+			cmd = "M"
+		}
+
+		_, _ = fmt.Fprintf(w, "%s%.2f %.2f ", cmd, cursor.Position.X, cursor.Position.Y)
+	}
+
+	_, _ = fmt.Fprintf(w, "\"/>\n</svg>\n")
+
+	if err = w.Flush(); err != nil {
+		return fmt.Errorf("failed to write SVG file: %w", err)
+	}
+
+	return nil
+}
@@ -58,6 +58,7 @@ type subControl struct {
 	relaxController *input.RelaxInputProcessor
 	mouseController schedulers.Scheduler
 	mods            difficulty.Modifier
+	replayMD5       string
 }
 
 func NewSubControl() *subControl {
@@ -126,6 +127,7 @@ func (controller *ReplayController) SetBeatMap(beatMap *beatmap.BeatMap) {
 
 		control := NewSubControl()
 		control.mods = difficulty.Modifier(replay.Mods)
+		control.replayMD5 = replay.ReplayMD5
 
 		log.Println("\tMods:", control.mods.String())
 
@@ -374,6 +376,10 @@ func (controller *ReplayController) InitCursors() {
 	controller.ruleset = osu.NewOsuRuleset(controller.bMap, controller.cursors, modifiers)
 
 	for i := range controller.controllers {
+		if controller.controllers[i].replayMD5 != "" {
+			controller.ruleset.SetReplayHash(controller.cursors[i], controller.controllers[i].replayMD5)
+		}
+
 		if controller.replays[i].ModsV.Active(difficulty.Relax) {
 			controller.controllers[i].relaxController = input.NewRelaxInputProcessor(controller.ruleset, controller.cursors[i])
 		}
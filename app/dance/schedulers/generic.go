@@ -45,7 +45,7 @@ func (scheduler *GenericScheduler) Init(objs []objects.IHitObject, diff *difficu
 	// Convert spinners to pseudo spinners that have beginning and ending angles, simplifies mover codes as well
 	for i := 0; i < len(scheduler.queue); i++ {
 		if s, ok := scheduler.queue[i].(*objects.Spinner); ok {
-			scheduler.queue[i] = spinners.NewSpinner(s, spinnerMoverCtor, scheduler.index)
+			scheduler.queue[i] = spinners.NewSpinner(s, diff, spinnerMoverCtor, scheduler.index)
 		}
 	}
 
@@ -103,6 +103,7 @@ func (scheduler *GenericScheduler) Init(objs []objects.IHitObject, diff *difficu
 func (scheduler *GenericScheduler) Update(time float64) {
 	if len(scheduler.queue) > 0 {
 		useMover := true
+		followingLong := false
 		lastEndTime := 0.0
 
 		for i := 0; i < len(scheduler.queue); i++ {
@@ -123,6 +124,10 @@ func (scheduler *GenericScheduler) Update(time float64) {
 				}
 
 				scheduler.cursor.SetPos(scheduler.mover.GetObjectsPosition(time, g))
+
+				if _, ok := g.(objects.ILongObject); ok && time >= gStartTime && time <= gEndTime {
+					followingLong = true
+				}
 			}
 
 			if time > gEndTime {
@@ -147,6 +152,11 @@ func (scheduler *GenericScheduler) Update(time float64) {
 			}
 		}
 
+		// Mover that hasn't started yet would snap the cursor to its start point, cutting through the slider that's being followed
+		if followingLong && scheduler.mover.GetStartTime() > time {
+			useMover = false
+		}
+
 		if useMover && scheduler.mover.GetEndTime() >= time {
 			scheduler.cursor.SetPos(scheduler.mover.Update(time))
 		}
@@ -0,0 +1,66 @@
+package schedulers
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap"
+	"github.com/wieku/danser-go/app/beatmap/objects"
+	"github.com/wieku/danser-go/app/dance/movers"
+	"github.com/wieku/danser-go/app/dance/spinners"
+	"github.com/wieku/danser-go/app/graphics"
+	"github.com/wieku/danser-go/app/settings"
+)
+
+func TestFollowCurvedSlider(t *testing.T) {
+	dir, err := filepath.Abs(filepath.Join("..", "..", "rulesets", "osu", "testdata"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	songsDir := settings.General.OsuSongsDir
+	settings.General.OsuSongsDir = dir
+
+	t.Cleanup(func() { settings.General.OsuSongsDir = songsDir })
+
+	beatMap := beatmap.NewBeatMap()
+	beatMap.Dir = "maps"
+	beatMap.File = "curved.osu"
+
+	if err = beatmap.ParseBeatMap(beatMap); err != nil {
+		t.Fatal(err)
+	}
+
+	beatmap.ParseObjects(beatMap, false, false)
+
+	// curved.osu has a half circle slider going up from 128x192 to 256x192 between two circles
+	slider, ok := beatMap.HitObjects[1].(*objects.Slider)
+	if !ok {
+		t.Fatal("second object is not a slider")
+	}
+
+	mods := beatMap.Diff.Mods
+
+	if mid := slider.GetStackedPositionAtMod((slider.GetStartTime()+slider.GetEndTime())/2, mods); mid.Y > 150 {
+		t.Fatalf("slider is not curved, its middle is at %.0fx%.0f", mid.X, mid.Y)
+	}
+
+	cursor := graphics.NewHeadlessCursor()
+
+	scheduler := NewGenericScheduler(movers.NewLinearMover, 0, 0)
+	scheduler.Init(beatMap.GetObjectsCopy(), beatMap.Diff, cursor, spinners.GetMoverCtorByName("circle"), false)
+
+	for time := 0.0; time <= slider.GetEndTime(); time++ {
+		scheduler.Update(time)
+
+		if time < slider.GetStartTime() {
+			continue
+		}
+
+		expected := slider.GetStackedPositionAtMod(time, mods)
+
+		if dst := cursor.RawPosition.Dst(expected); dst > 0.01 {
+			t.Fatalf("cursor left the slider path at %.0fms: expected %.1fx%.1f, got %.1fx%.1f", time, expected.X, expected.Y, cursor.RawPosition.X, cursor.RawPosition.Y)
+		}
+	}
+}
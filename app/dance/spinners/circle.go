@@ -9,18 +9,20 @@ import (
 type CircleMover struct {
 	start float64
 	id    int
+	speed float32
 }
 
 func NewCircleMover() *CircleMover {
 	return &CircleMover{}
 }
 
-func (c *CircleMover) Init(start, _ float64, id int) {
+func (c *CircleMover) Init(start, _ float64, id int, speed float32) {
 	c.start = start
 	c.id = id
+	c.speed = speed
 }
 
 func (c *CircleMover) GetPositionAt(time float64) vector.Vector2f {
 	spS := settings.CursorDance.Spinners[c.id%len(settings.CursorDance.Spinners)]
-	return vector.NewVec2fRad(rpms*float32(time-c.start)*2*math32.Pi, float32(spS.Radius)).Add(center.AddS(float32(spS.CenterOffsetX), float32(spS.CenterOffsetY)))
+	return vector.NewVec2fRad(c.speed*float32(time-c.start)*2*math32.Pi, float32(spS.Radius)).Add(center.AddS(float32(spS.CenterOffsetX), float32(spS.CenterOffsetY)))
 }
@@ -30,7 +30,7 @@ func NewCubeMover() *CubeMover {
 	return &CubeMover{}
 }
 
-func (c *CubeMover) Init(start, _ float64, id int) {
+func (c *CubeMover) Init(start, _ float64, id int, _ float32) {
 	c.start = start
 	c.id = id
 }
@@ -1,16 +1,23 @@
 package spinners
 
 import (
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+	"github.com/wieku/danser-go/app/settings"
 	"github.com/wieku/danser-go/framework/math/vector"
+	"math"
 	"strings"
 )
 
+// rpms is the max spin speed in rotations per millisecond (~477 RPM)
 const rpms = 0.00795
 
+// clearMargin is added to the calculated speed so that frame timing inaccuracies won't leave the spinner uncleared
+const clearMargin = 1.1
+
 var center = vector.NewVec2f(256, 192)
 
 type SpinnerMover interface {
-	Init(start, end float64, id int)
+	Init(start, end float64, id int, speed float32)
 	GetPositionAt(time float64) vector.Vector2f
 }
 
@@ -34,3 +41,40 @@ func GetMoverCtorByName(name string) func() SpinnerMover {
 		return GetMoverByName(name)
 	}
 }
+
+// GetRotationSpeed returns spin speed in rotations per millisecond for a spinner, based on spinner settings of given cursor
+func GetRotationSpeed(start, end float64, diff *difficulty.Difficulty, id int) float32 {
+	spS := settings.CursorDance.Spinners[id%len(settings.CursorDance.Spinners)]
+
+	switch spS.SpinSpeed {
+	case "custom":
+		return float32(math.Min(spS.RPM/60000, rpms))
+	case "clear":
+		return getClearSpeed(end-start, diff)
+	}
+
+	return rpms
+}
+
+// getClearSpeed calculates the lowest speed that gives 300 on a spinner, taking ruleset's spin-up into account
+func getClearSpeed(duration float64, diff *difficulty.Difficulty) float32 {
+	if duration <= 0 {
+		return rpms
+	}
+
+	requirement := math.Floor(duration / 1000 * diff.SpinnerRatio)
+
+	// ruleset counts half rotations, +2 covers the requirement of both new and old spinner scoring
+	angle := (requirement + 2) * math.Pi
+
+	accel := (0.00008 + math.Max(0, (5000-duration)/1000/2000)) / diff.Speed
+
+	delta := duration*duration - 2*angle/accel
+	if delta < 0 {
+		return rpms
+	}
+
+	velocity := accel * (duration - math.Sqrt(delta)) * clearMargin
+
+	return float32(math.Min(velocity/(2*math.Pi), rpms))
+}
@@ -19,7 +19,7 @@ func NewSquareMover() *SquareMover {
 	return &SquareMover{}
 }
 
-func (c *SquareMover) Init(start, _ float64, id int) {
+func (c *SquareMover) Init(start, _ float64, id int, _ float32) {
 	c.start = start
 	c.id = id
 }
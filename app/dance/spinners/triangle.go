@@ -19,7 +19,7 @@ func NewTriangleMover() *TriangleMover {
 	return &TriangleMover{}
 }
 
-func (c *TriangleMover) Init(start, _ float64, id int) {
+func (c *TriangleMover) Init(start, _ float64, id int, _ float32) {
 	c.start = start
 	c.id = id
 }
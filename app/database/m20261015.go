@@ -0,0 +1,27 @@
+package database
+
+import (
+	"github.com/wieku/danser-go/app/beatmap"
+)
+
+type M20261015 struct{}
+
+func (m *M20261015) RequiredSections() []string {
+	return nil
+}
+
+func (m *M20261015) FieldsToMigrate() []string {
+	return nil
+}
+
+func (m *M20261015) GetValues(_ *beatmap.BeatMap) []interface{} {
+	return nil
+}
+
+func (m *M20261015) Date() int {
+	return 20261015
+}
+
+func (m *M20261015) GetMigrationStmts() string {
+	return "ALTER TABLE beatmaps ADD COLUMN aimTapRatio REAL DEFAULT -1;"
+}
@@ -27,7 +27,7 @@ import (
 
 var dbFile *sql.DB
 
-const databaseVersion = 20220622
+const databaseVersion = 20261015
 
 var currentPreVersion = databaseVersion
 var currentSchemaPreVersion = databaseVersion
@@ -67,6 +67,7 @@ func Init() error {
 		&M20210423{},
 		&M20220605{},
 		&M20220622{},
+		&M20261015{},
 	}
 
 	dbFile, err = sql.Open("sqlite3", filepath.Join(env.DataDir(), "danser.db"))
@@ -75,7 +76,7 @@ func Init() error {
 	}
 
 	_, err = dbFile.Exec(`
-		CREATE TABLE IF NOT EXISTS beatmaps (dir TEXT, file TEXT, lastModified INTEGER, title TEXT, titleUnicode TEXT, artist TEXT, artistUnicode TEXT, creator TEXT, version TEXT, source TEXT, tags TEXT, cs REAL, ar REAL, sliderMultiplier REAL, sliderTickRate REAL, audioFile TEXT, previewTime INTEGER, sampleSet INTEGER, stackLeniency REAL, mode INTEGER, bg TEXT, md5 TEXT, dateAdded INTEGER, playCount INTEGER, lastPlayed INTEGER, hpdrain REAL, od REAL, stars REAL DEFAULT -1, bpmMin REAL, bpmMax REAL, circles INTEGER, sliders INTEGER, spinners INTEGER, endTime INTEGER, setID INTEGER, mapID INTEGER, starsVersion INTEGER DEFAULT 0, localOffset INTEGER DEFAULT 0);
+		CREATE TABLE IF NOT EXISTS beatmaps (dir TEXT, file TEXT, lastModified INTEGER, title TEXT, titleUnicode TEXT, artist TEXT, artistUnicode TEXT, creator TEXT, version TEXT, source TEXT, tags TEXT, cs REAL, ar REAL, sliderMultiplier REAL, sliderTickRate REAL, audioFile TEXT, previewTime INTEGER, sampleSet INTEGER, stackLeniency REAL, mode INTEGER, bg TEXT, md5 TEXT, dateAdded INTEGER, playCount INTEGER, lastPlayed INTEGER, hpdrain REAL, od REAL, stars REAL DEFAULT -1, bpmMin REAL, bpmMax REAL, circles INTEGER, sliders INTEGER, spinners INTEGER, endTime INTEGER, setID INTEGER, mapID INTEGER, starsVersion INTEGER DEFAULT 0, localOffset INTEGER DEFAULT 0, aimTapRatio REAL DEFAULT -1);
 		CREATE INDEX IF NOT EXISTS idx ON beatmaps (dir, file);
 		CREATE TABLE IF NOT EXISTS info (key TEXT NOT NULL UNIQUE, value TEXT);
 	`)
@@ -428,7 +429,7 @@ func UpdateStarRating(maps []*beatmap.BeatMap, progressListener func(processed,
 	var toCalculate []*beatmap.BeatMap
 
 	for _, b := range maps {
-		if b.Mode == 0 && (b.Stars < 0 || b.StarsVersion < pp220930.CurrentVersion) {
+		if b.Mode == 0 && (b.Stars < 0 || b.AimTapRatio < 0 || b.StarsVersion < pp220930.CurrentVersion) {
 			toCalculate = append(toCalculate, b)
 		}
 	}
@@ -453,6 +454,7 @@ func UpdateStarRating(maps []*beatmap.BeatMap, progressListener func(processed,
 
 				if err := recover(); err != nil { //TODO: Technically should be fixed but unexpected parsing problem won't crash whole process
 					bMap.Stars = 0
+					bMap.AimTapRatio = 0
 					log.Println("DatabaseManager: Failed to load \"", bMap.Dir+"/"+bMap.File, "\":", err)
 				}
 			}()
@@ -463,9 +465,15 @@ func UpdateStarRating(maps []*beatmap.BeatMap, progressListener func(processed,
 			if len(bMap.HitObjects) < 2 {
 				log.Println("DatabaseManager:", bMap.Dir+"/"+bMap.File, "doesn't have enough hitobjects")
 				bMap.Stars = 0
+				bMap.AimTapRatio = 0
 			} else {
 				attr := pp220930.CalculateSingle(bMap.HitObjects, bMap.Diff)
 				bMap.Stars = attr.Total
+
+				pp := &pp220930.PPv2{}
+				pp.PPv2x(attr, -1, -1, 0, 0, 0, bMap.Diff)
+
+				bMap.AimTapRatio = pp.Results.AimTapRatio()
 			}
 
 			return bMap
@@ -505,7 +513,7 @@ func pushSRToDB(maps []*beatmap.BeatMap) {
 		panic(err)
 	}
 
-	st, err := tx.Prepare("UPDATE beatmaps SET stars = ?, starsVersion = ? WHERE dir = ? AND file = ?")
+	st, err := tx.Prepare("UPDATE beatmaps SET stars = ?, starsVersion = ?, aimTapRatio = ? WHERE dir = ? AND file = ?")
 	if err != nil {
 		panic(err)
 	}
@@ -514,6 +522,7 @@ func pushSRToDB(maps []*beatmap.BeatMap) {
 		_, err1 := st.Exec(
 			bMap.Stars,
 			bMap.StarsVersion,
+			bMap.AimTapRatio,
 			bMap.Dir,
 			bMap.File)
 
@@ -678,7 +687,7 @@ func insertBeatmaps(bMaps []*beatmap.BeatMap) {
 
 	if err == nil {
 		var st *sql.Stmt
-		st, err = tx.Prepare("INSERT INTO beatmaps VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+		st, err = tx.Prepare("INSERT INTO beatmaps VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
 
 		if err == nil {
 			for _, bMap := range bMaps {
@@ -721,6 +730,7 @@ func insertBeatmaps(bMaps []*beatmap.BeatMap) {
 					bMap.ID,
 					bMap.StarsVersion,
 					bMap.LocalOffset,
+					bMap.AimTapRatio,
 				)
 
 				if err1 != nil {
@@ -789,6 +799,7 @@ func loadBeatmapsFromDatabase() []*beatmap.BeatMap {
 			&beatMap.ID,
 			&beatMap.StarsVersion,
 			&beatMap.LocalOffset,
+			&beatMap.AimTapRatio,
 		)
 
 		beatMap.Diff.SetCS(mutils.ClampF(cs, 0, 10))
@@ -128,6 +128,27 @@ func NewCursor() *Cursor {
 	return cursor
 }
 
+// NewHeadlessCursor creates a cursor that doesn't need graphics context, it can only be moved and updated, not drawn
+func NewHeadlessCursor() *Cursor {
+	return &Cursor{
+		Position:        vector.NewVec2f(100, 100),
+		scale:           animation.NewGlider(1.0),
+		renderer:        headlessRenderer{},
+		smokeContainer:  sprite.NewManager(),
+		rippleContainer: sprite.NewManager(),
+	}
+}
+
+type headlessRenderer struct{}
+
+func (headlessRenderer) SetPosition(_ vector.Vector2f) {}
+
+func (headlessRenderer) Update(_ float64) {}
+
+func (headlessRenderer) UpdateRenderer() {}
+
+func (headlessRenderer) DrawM(_, _ float64, _ *batch.QuadBatch, _ color2.Color, _ color2.Color) {}
+
 func (cursor *Cursor) SetPos(pt vector.Vector2f) {
 	cursor.RawPosition = pt
 	tmp := pt
@@ -0,0 +1,54 @@
+package osu
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+)
+
+func TestScoreV2DisplayAccuracy(t *testing.T) {
+	beatMap := loadTestMap(t, "sliders.osu")
+
+	// Test player clicks slider heads perfectly but releases before slider ends
+	ruleset, cursor := newTestRuleset(t, beatMap, difficulty.ScoreV2, 0)
+	ruleset.RunToEnd(1)
+
+	score := ruleset.GetScore(cursor)
+
+	objectCount := uint(len(beatMap.HitObjects))
+
+	if score.Count100 != objectCount {
+		t.Fatalf("expected %d 100s, got 300: %d, 100: %d, 50: %d, miss: %d", objectCount, score.Count300, score.Count100, score.Count50, score.CountMiss)
+	}
+
+	// pp and grades use object judgements only
+	if math.Abs(score.Accuracy-100.0/3) > 1e-9 {
+		t.Errorf("expected count-based accuracy of 33.33%%, got %.4f%%", score.Accuracy)
+	}
+
+	// lazer weights each slider as head (30) + end (150) + object judgement (300): (30 + 0 + 100) / 480
+	expected := 100 * 130.0 / 480
+
+	accuracy := ruleset.GetDisplayAccuracy(cursor)
+
+	if math.Abs(accuracy-expected) > 1e-9 {
+		t.Errorf("expected lazer accuracy of %.4f%%, got %.4f%%", expected, accuracy)
+	}
+
+	// danser floors displayed ScoreV2 accuracy to two decimals
+	if displayed := FloorAccuracy(accuracy); displayed != 27.08 {
+		t.Errorf("expected FloorAccuracy to give 27.08%%, got %.2f%%", displayed)
+	}
+}
+
+func TestScoreV1DisplayAccuracy(t *testing.T) {
+	beatMap := loadTestMap(t, "sliders.osu")
+
+	ruleset, cursor := newTestRuleset(t, beatMap, difficulty.None, 0)
+	ruleset.RunToEnd(1)
+
+	if accuracy, expected := ruleset.GetDisplayAccuracy(cursor), ruleset.GetScore(cursor).Accuracy; accuracy != expected {
+		t.Errorf("expected ScoreV1 to display count-based accuracy of %.4f%%, got %.4f%%", expected, accuracy)
+	}
+}
@@ -0,0 +1,33 @@
+package osu
+
+import (
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+)
+
+func TestGetAimTapRatio(t *testing.T) {
+	tests := []struct {
+		file      string
+		aimHeavy  bool
+		mapSketch string
+	}{
+		{"jumps.osu", true, "full screen jumps 250ms apart"},
+		{"stream.osu", false, "8 o!px spaced stream 75ms apart"},
+	}
+
+	for _, tt := range tests {
+		ruleset, _ := newTestRuleset(t, loadTestMap(t, tt.file), difficulty.None, 0)
+
+		ratio := ruleset.GetAimTapRatio(difficulty.None)
+
+		if ratio <= 0 {
+			t.Errorf("%s (%s): expected positive ratio, got %.2f", tt.file, tt.mapSketch, ratio)
+			continue
+		}
+
+		if aimHeavy := ratio > 1; aimHeavy != tt.aimHeavy {
+			t.Errorf("%s (%s): expected aim-heavy to be %t, got ratio %.2f", tt.file, tt.mapSketch, tt.aimHeavy, ratio)
+		}
+	}
+}
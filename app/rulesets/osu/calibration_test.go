@@ -0,0 +1,46 @@
+package osu
+
+import (
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+	"github.com/wieku/danser-go/app/settings"
+)
+
+func TestCalibrationEarlyBias(t *testing.T) {
+	previous := settings.Gameplay.CalibrationObjects
+	settings.Gameplay.CalibrationObjects = 10
+
+	t.Cleanup(func() {
+		settings.Gameplay.CalibrationObjects = previous
+	})
+
+	beatMap := loadTestMap(t, "circles.osu")
+
+	// 40ms early is outside OD8's 300 window (32ms) but inside the 100 window
+	ruleset, cursor := newTestRuleset(t, beatMap, difficulty.None, -40)
+	ruleset.RunToEnd(1)
+
+	if offset := ruleset.GetCalibrationOffset(cursor); offset != 40 {
+		t.Fatalf("expected +40ms calibration offset, got %+dms", offset)
+	}
+
+	score := ruleset.GetScore(cursor)
+
+	if score.Count100 != 10 {
+		t.Errorf("expected 10 100s before calibration, got %d", score.Count100)
+	}
+
+	if want := uint(len(beatMap.HitObjects) - 10); score.Count300 != want {
+		t.Errorf("expected %d 300s after calibration, got %d", want, score.Count300)
+	}
+
+	// hit errors start over after calibration, the rest was hit on time
+	if bias := ruleset.GetTimingBias(cursor); bias != 0 {
+		t.Errorf("expected no timing bias after calibration, got %.2fms", bias)
+	}
+
+	if ur := ruleset.GetUnstableRate(cursor); ur != 0 {
+		t.Errorf("expected 0 UR after calibration, got %.2f", ur)
+	}
+}
@@ -0,0 +1,62 @@
+package osu
+
+import (
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+	"github.com/wieku/danser-go/app/graphics"
+)
+
+func runDesyncCheck(t *testing.T, offset int64) (warned bool, ratio float64) {
+	t.Helper()
+
+	beatMap := loadTestMap(t, "circles.osu")
+
+	ruleset, _ := newTestRuleset(t, beatMap, difficulty.None, offset)
+
+	ruleset.SetDesyncListener(func(_ *graphics.Cursor, r float64) {
+		if warned {
+			t.Error("desync listener called more than once")
+		}
+
+		warned = true
+		ratio = r
+	})
+
+	ruleset.RunToEnd(1)
+
+	return
+}
+
+func TestDesyncAlignedReplay(t *testing.T) {
+	if warned, ratio := runDesyncCheck(t, 0); warned {
+		t.Errorf("aligned replay reported as desynced, ratio: %.2f", ratio)
+	}
+}
+
+func TestDesyncMisalignedReplay(t *testing.T) {
+	// Every click lands 200ms after its object, past the 50 window, and too early and off-position for the next one
+	warned, ratio := runDesyncCheck(t, 200)
+
+	if !warned {
+		t.Fatal("misaligned replay wasn't reported")
+	}
+
+	if ratio < desyncThreshold || ratio > 1 {
+		t.Errorf("expected ratio between %.2f and 1, got %.2f", desyncThreshold, ratio)
+	}
+}
+
+func TestDesyncShakesCountedOncePerObject(t *testing.T) {
+	// Clicks 150ms early shake the objects (OD8 50 window is 120ms) and they are missed afterwards,
+	// so every early object is both shaken and missed but may be counted only once
+	warned, ratio := runDesyncCheck(t, -150)
+
+	if !warned {
+		t.Fatal("misaligned replay wasn't reported")
+	}
+
+	if ratio != 1 {
+		t.Errorf("expected ratio 1, got %.2f", ratio)
+	}
+}
@@ -0,0 +1,60 @@
+package osu
+
+import (
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+	"github.com/wieku/danser-go/app/graphics"
+	"github.com/wieku/danser-go/app/settings"
+	"github.com/wieku/danser-go/framework/math/vector"
+)
+
+func TestEarlyClick(t *testing.T) {
+	earlyClicksMiss, earlyMissWindow := settings.Gameplay.EarlyClicksMiss, settings.Gameplay.EarlyMissWindow
+
+	t.Cleanup(func() {
+		settings.Gameplay.EarlyClicksMiss, settings.Gameplay.EarlyMissWindow = earlyClicksMiss, earlyMissWindow
+	})
+
+	tests := []struct {
+		name      string
+		enabled   bool
+		offset    int64
+		clickMiss bool // object is missed at click time instead of after its 50's window passes
+	}{
+		{"disabled", false, -450, false},
+		{"inside window", true, -450, true},
+		{"outside window", true, -700, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings.Gameplay.EarlyClicksMiss = tt.enabled
+			settings.Gameplay.EarlyMissWindow = 200
+
+			beatMap := loadTestMap(t, "circles.osu")
+
+			ruleset, _ := newTestRuleset(t, beatMap, difficulty.None, tt.offset)
+
+			missTime := int64(-1)
+
+			ruleset.SetListener(func(_ *graphics.Cursor, time int64, number int64, _ vector.Vector2d, result HitResult, _ ComboResult, _ PerformanceResult, _ int64) {
+				if number == 0 && result&BaseHitsM == Miss {
+					missTime = time
+				}
+			})
+
+			ruleset.RunToEnd(1)
+
+			startTime := int64(beatMap.HitObjects[0].GetStartTime())
+
+			if missTime < 0 {
+				t.Fatal("first object wasn't missed")
+			}
+
+			if clickMiss := missTime < startTime; clickMiss != tt.clickMiss {
+				t.Errorf("expected miss on click: %t, got miss at %dms with object at %dms", tt.clickMiss, missTime, startTime)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package osu
+
+import (
+	"github.com/wieku/danser-go/app/graphics"
+	"github.com/wieku/danser-go/framework/math/vector"
+	"sync"
+)
+
+// hitEventBuffer is the number of events a subscriber can fall behind before the oldest ones are dropped
+const hitEventBuffer = 256
+
+// HitEvent describes a single judgement, it carries the same data as the hit listener
+type HitEvent struct {
+	Cursor      *graphics.Cursor
+	Time        int64
+	Number      int64
+	Position    vector.Vector2d
+	Result      HitResult
+	ComboResult ComboResult
+	PP          PerformanceResult
+	Score       int64
+}
+
+type hitSubscribers struct {
+	mutex    sync.Mutex
+	channels []chan HitEvent
+}
+
+// Subscribe returns a buffered channel receiving every judgement of all cursors, meant for consumers on other goroutines.
+// Sending never blocks gameplay, if the consumer falls behind the oldest events are dropped.
+// The channel is closed when the ruleset is disposed.
+func (set *OsuRuleSet) Subscribe() <-chan HitEvent {
+	channel := make(chan HitEvent, hitEventBuffer)
+
+	set.subscribers.mutex.Lock()
+	set.subscribers.channels = append(set.subscribers.channels, channel)
+	set.subscribers.mutex.Unlock()
+
+	return channel
+}
+
+// notifyHit passes judgement to the hit listener and subscribers
+func (set *OsuRuleSet) notifyHit(event HitEvent) {
+	if set.hitListener != nil {
+		set.hitListener(event.Cursor, event.Time, event.Number, event.Position, event.Result, event.ComboResult, event.PP, event.Score)
+	}
+
+	set.subscribers.mutex.Lock()
+	defer set.subscribers.mutex.Unlock()
+
+	for _, channel := range set.subscribers.channels {
+		for sent := false; !sent; {
+			select {
+			case channel <- event:
+				sent = true
+			default:
+				select {
+				case <-channel:
+				default:
+				}
+			}
+		}
+	}
+}
+
+func (set *OsuRuleSet) closeSubscribers() {
+	set.subscribers.mutex.Lock()
+	defer set.subscribers.mutex.Unlock()
+
+	for _, channel := range set.subscribers.channels {
+		close(channel)
+	}
+
+	set.subscribers.channels = nil
+}
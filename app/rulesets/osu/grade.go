@@ -1,5 +1,7 @@
 package osu
 
+import "github.com/wieku/danser-go/app/beatmap/difficulty"
+
 type Grade uint8
 
 const (
@@ -14,6 +16,72 @@ const (
 	SSH
 )
 
+// GradeThreshold describes the ratio of 300s that has to be exceeded to get a grade, SS requires all 300s
+type GradeThreshold struct {
+	Grade      Grade
+	MinRatio   float64 // Ratio needed without misses
+	MissRatio  float64 // Ratio needed with misses, above 1 if grade can't be achieved with misses
+	Max50Ratio float64 // Ratio of 50s that has to be stayed below, above 1 if 50s don't matter
+}
+
+// gradeThresholds are ordered from the highest grade
+var gradeThresholds = []GradeThreshold{
+	{Grade: SS, MinRatio: 1, MissRatio: 1, Max50Ratio: 0.01},
+	{Grade: S, MinRatio: 0.9, MissRatio: 2, Max50Ratio: 0.01},
+	{Grade: A, MinRatio: 0.8, MissRatio: 0.9, Max50Ratio: 2},
+	{Grade: B, MinRatio: 0.7, MissRatio: 0.8, Max50Ratio: 2},
+	{Grade: _C, MinRatio: 0.6, MissRatio: 0.6, Max50Ratio: 2},
+	{Grade: D, MinRatio: 0, MissRatio: 0, Max50Ratio: 2},
+}
+
+// GetGradeThresholds returns grade ladder used by ComputeGrade, from the highest grade to the lowest
+func GetGradeThresholds(mods difficulty.Modifier) []GradeThreshold {
+	thresholds := make([]GradeThreshold, len(gradeThresholds))
+
+	for i, t := range gradeThresholds {
+		t.Grade = silverGrade(t.Grade, mods)
+		thresholds[i] = t
+	}
+
+	return thresholds
+}
+
+// ComputeGrade returns osu!stable grade for given hit counts, total is the number of judged objects
+func ComputeGrade(count300, count100, count50, miss, total uint, mods difficulty.Modifier) Grade {
+	if count300 == total {
+		return silverGrade(SS, mods)
+	}
+
+	ratio := float64(count300) / float64(total)
+
+	for _, t := range gradeThresholds[1 : len(gradeThresholds)-1] {
+		if float64(count50)/float64(total) >= t.Max50Ratio {
+			continue
+		}
+
+		if miss == 0 && ratio > t.MinRatio || ratio > t.MissRatio {
+			return silverGrade(t.Grade, mods)
+		}
+	}
+
+	return D
+}
+
+func silverGrade(grade Grade, mods difficulty.Modifier) Grade {
+	if mods&(difficulty.Hidden|difficulty.Flashlight) == 0 {
+		return grade
+	}
+
+	switch grade {
+	case SS:
+		return SSH
+	case S:
+		return SH
+	}
+
+	return grade
+}
+
 func (grade Grade) String() string {
 	switch grade {
 	case D:
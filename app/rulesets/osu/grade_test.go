@@ -0,0 +1,81 @@
+package osu
+
+import (
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+)
+
+func TestComputeGrade(t *testing.T) {
+	tests := []struct {
+		name                  string
+		n300, n100, n50, miss uint
+		mods                  difficulty.Modifier
+		want                  Grade
+	}{
+		{"all 300s", 100, 0, 0, 0, difficulty.None, SS},
+		{"all 300s with HD", 100, 0, 0, 0, difficulty.Hidden, SSH},
+		{"all 300s with FL", 100, 0, 0, 0, difficulty.Flashlight, SSH},
+		{"above 90%", 91, 9, 0, 0, difficulty.None, S},
+		{"above 90% with HD", 91, 9, 0, 0, difficulty.Hidden, SH},
+		{"above 90% with FL", 91, 9, 0, 0, difficulty.Flashlight, SH},
+		{"exactly 90%", 90, 10, 0, 0, difficulty.None, A},
+		{"1% 50s", 95, 4, 1, 0, difficulty.None, A},
+		{"below 1% 50s", 991, 0, 9, 0, difficulty.None, S},
+		{"above 90% with a miss", 95, 4, 0, 1, difficulty.None, A},
+		{"A with HD stays A", 85, 15, 0, 0, difficulty.Hidden, A},
+		{"above 80%", 81, 19, 0, 0, difficulty.None, A},
+		{"above 80% with a miss", 81, 18, 0, 1, difficulty.None, B},
+		{"exactly 80%", 80, 20, 0, 0, difficulty.None, B},
+		{"above 70%", 71, 29, 0, 0, difficulty.None, B},
+		{"above 70% with a miss", 71, 28, 0, 1, difficulty.None, _C},
+		{"exactly 70%", 70, 30, 0, 0, difficulty.None, _C},
+		{"above 60%", 61, 39, 0, 0, difficulty.None, _C},
+		{"exactly 60%", 60, 40, 0, 0, difficulty.None, D},
+		{"all misses", 0, 0, 0, 100, difficulty.None, D},
+	}
+
+	for _, tt := range tests {
+		total := tt.n300 + tt.n100 + tt.n50 + tt.miss
+
+		if got := ComputeGrade(tt.n300, tt.n100, tt.n50, tt.miss, total, tt.mods); got != tt.want {
+			t.Errorf("%s: ComputeGrade(%d, %d, %d, %d) = %s, want %s", tt.name, tt.n300, tt.n100, tt.n50, tt.miss, got.String(), tt.want.String())
+		}
+	}
+}
+
+func TestGetGradeThresholds(t *testing.T) {
+	thresholds := GetGradeThresholds(difficulty.Hidden)
+
+	if thresholds[0].Grade != SSH || thresholds[1].Grade != SH {
+		t.Errorf("expected silver grades with HD, got %s and %s", thresholds[0].Grade.String(), thresholds[1].Grade.String())
+	}
+
+	// 1000 objects so that ratios just above the thresholds can be expressed
+	const total = 1000
+
+	for _, tt := range GetGradeThresholds(difficulty.None)[1:] {
+		n300 := uint(tt.MinRatio*total) + 1
+
+		if got := ComputeGrade(n300, total-n300, 0, 0, total, difficulty.None); got != tt.Grade {
+			t.Errorf("%s: %d 300s out of %d should give %s, got %s", tt.Grade.String(), n300, total, tt.Grade.String(), got.String())
+		}
+
+		if tt.MissRatio <= 1 {
+			n300 = uint(tt.MissRatio*total) + 1
+
+			if got := ComputeGrade(n300, total-n300-1, 0, 1, total, difficulty.None); got != tt.Grade {
+				t.Errorf("%s: %d 300s and a miss out of %d should give %s, got %s", tt.Grade.String(), n300, total, tt.Grade.String(), got.String())
+			}
+		}
+
+		if tt.Max50Ratio <= 1 {
+			n50 := uint(tt.Max50Ratio * total)
+			n300 = total - n50
+
+			if got := ComputeGrade(n300, 0, n50, 0, total, difficulty.None); got == tt.Grade {
+				t.Errorf("%s: %d 50s out of %d shouldn't give %s", tt.Grade.String(), n50, total, tt.Grade.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package osu
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+	"github.com/wieku/danser-go/app/beatmap/objects"
+)
+
+// headlessTail is how long RunToEnd keeps updating after the last object's end before giving up
+const headlessTail = 5000
+
+// RunToEnd advances a virtual clock in fixed steps and updates the ruleset until all objects are judged.
+// Cursors are not moved by the ruleset itself, use SetStepListener to drive them.
+func (set *OsuRuleSet) RunToEnd(step int64) {
+	objs := set.beatMap.HitObjects
+
+	set.run(objs[0], objs[len(objs)-1], step)
+}
+
+// RunRange works like RunToEnd but judges only objects starting within [start, end] milliseconds, so accuracy and combo
+// describe only that section of the map. It has to be called on a fresh ruleset.
+//
+// Objects before the window are never judged, so combo starts from 0 at the window start instead of carrying over
+// what a full play would have built up there. PP isn't calculated if objects before the window are skipped, as both
+// calculators can only score a map from its beginning. Objects judged before and restored with LoadState are not skipped.
+func (set *OsuRuleSet) RunRange(start, end float64, step int64) error {
+	if start > end {
+		return fmt.Errorf("invalid range: %.0fms-%.0fms", start, end)
+	}
+
+	if set.ended || len(set.processed) > 0 {
+		return errors.New("ruleset has already been updated")
+	}
+
+	queue := make([]HitObject, 0, len(set.queue))
+	skipped := uint(0)
+
+	for _, obj := range set.queue {
+		startTime := set.beatMap.HitObjects[obj.GetNumber()].GetStartTime()
+
+		if startTime >= start && startTime <= end {
+			queue = append(queue, obj)
+		} else if startTime < start {
+			skipped++
+		}
+	}
+
+	if len(queue) == 0 {
+		return fmt.Errorf("no objects in range: %.0fms-%.0fms", start, end)
+	}
+
+	resumed := false
+
+	for _, subSet := range set.cursors {
+		if subSet.numObjects > 0 && subSet.numObjects != skipped {
+			return fmt.Errorf("loaded state has %d judged objects but %d are before the range", subSet.numObjects, skipped)
+		}
+
+		resumed = subSet.numObjects > 0
+	}
+
+	set.queue = queue
+
+	if skipped > 0 && !resumed {
+		set.setPartial()
+	}
+
+	set.run(set.beatMap.HitObjects[queue[0].GetNumber()], set.beatMap.HitObjects[queue[len(queue)-1].GetNumber()], step)
+
+	return nil
+}
+
+func (set *OsuRuleSet) run(first, last objects.IHitObject, step int64) {
+	if step <= 0 {
+		step = 1
+	}
+
+	startTime := int64(first.GetStartTime() - difficulty.HitFadeIn - set.beatMap.Diff.Preempt)
+	endTime := int64(last.GetEndTime()) + headlessTail
+
+	for time := startTime; time <= endTime && !set.ended; time += step {
+		if set.stepListener != nil {
+			set.stepListener(time)
+		}
+
+		for cursor := range set.cursors {
+			cursor.LastFrameTime = time - step
+			cursor.CurrentFrameTime = time
+			cursor.IsReplayFrame = true
+
+			set.UpdateClickFor(cursor, time)
+			set.UpdateNormalFor(cursor, time, false)
+			set.UpdatePostFor(cursor, time, false)
+		}
+
+		set.Update(time)
+	}
+}
@@ -0,0 +1,121 @@
+package osu
+
+import (
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+	"github.com/wieku/danser-go/app/graphics"
+	"github.com/wieku/danser-go/framework/math/mutils"
+	"github.com/wieku/danser-go/framework/math/vector"
+)
+
+func TestRunToEndSS(t *testing.T) {
+	beatMap := loadTestMap(t, "circles.osu")
+
+	ruleset, cursor := newTestRuleset(t, beatMap, difficulty.None, 0)
+	ruleset.RunToEnd(1)
+
+	score := ruleset.GetScore(cursor)
+
+	objectCount := uint(len(beatMap.HitObjects))
+
+	if score.Count300 != objectCount || score.Count100 != 0 || score.Count50 != 0 || score.CountMiss != 0 {
+		t.Fatalf("expected %d 300s, got 300: %d, 100: %d, 50: %d, miss: %d", objectCount, score.Count300, score.Count100, score.Count50, score.CountMiss)
+	}
+
+	if score.Accuracy != 100 {
+		t.Errorf("expected 100%% accuracy, got %.2f%%", score.Accuracy)
+	}
+
+	if score.Grade != SS {
+		t.Errorf("expected SS, got %s", score.Grade.String())
+	}
+
+	if score.Combo != objectCount {
+		t.Errorf("expected %dx combo, got %dx", objectCount, score.Combo)
+	}
+
+	if !ruleset.ended {
+		t.Error("ruleset didn't end")
+	}
+}
+
+func TestRunRange(t *testing.T) {
+	// circles.osu has OD8: 0 and 30ms late are 300s, 60ms late is a 100, object 12 is missed
+	script := func(number int) (int64, bool) {
+		return int64(number%3) * 30, number != 12
+	}
+
+	run := func(rangeRun bool) (map[int64]HitResult, Score) {
+		ruleset, cursors := newScriptedRuleset(t, loadTestMap(t, "circles.osu"), testPlayer{name: "test", mods: difficulty.None, script: script})
+
+		results := make(map[int64]HitResult)
+
+		ruleset.SetListener(func(_ *graphics.Cursor, _ int64, number int64, _ vector.Vector2d, result HitResult, _ ComboResult, _ PerformanceResult, _ int64) {
+			results[number] = result
+		})
+
+		if rangeRun {
+			// objects 10-20
+			if err := ruleset.RunRange(4000, 7000, 1); err != nil {
+				t.Fatal(err)
+			}
+		} else {
+			ruleset.RunToEnd(1)
+		}
+
+		return results, ruleset.GetScore(cursors[0])
+	}
+
+	fullResults, _ := run(false)
+	rangeResults, score := run(true)
+
+	if len(rangeResults) != 11 {
+		t.Fatalf("expected 11 judged objects, got %d", len(rangeResults))
+	}
+
+	var counts [4]uint
+
+	combo, maxCombo := uint(0), uint(0)
+
+	for number := int64(10); number <= 20; number++ {
+		result, ok := rangeResults[number]
+		if !ok {
+			t.Fatalf("object %d wasn't judged", number)
+		}
+
+		if result&BaseHitsM != fullResults[number]&BaseHitsM {
+			t.Errorf("object %d: expected %d like in a full run, got %d", number, fullResults[number]&BaseHitsM, result&BaseHitsM)
+		}
+
+		switch result & BaseHitsM {
+		case Hit300:
+			counts[0]++
+		case Hit100:
+			counts[1]++
+		case Hit50:
+			counts[2]++
+		case Miss:
+			counts[3]++
+		}
+
+		if result&BaseHitsM == Miss {
+			combo = 0
+		} else {
+			combo++
+			maxCombo = mutils.Max(maxCombo, combo)
+		}
+	}
+
+	if score.Count300 != counts[0] || score.Count100 != counts[1] || score.Count50 != counts[2] || score.CountMiss != counts[3] {
+		t.Errorf("expected counts %d/%d/%d/%d, got %d/%d/%d/%d", counts[0], counts[1], counts[2], counts[3], score.Count300, score.Count100, score.Count50, score.CountMiss)
+	}
+
+	if score.Combo != maxCombo {
+		t.Errorf("expected %dx combo counted from the range start, got %dx", maxCombo, score.Combo)
+	}
+
+	if score.PP != 0 {
+		t.Errorf("expected no pp for a range skipping the map start, got %.2f", score.PP)
+	}
+}
@@ -304,6 +304,116 @@ func (hp *HealthProcessor) Update(time int64) {
 	hp.lastTime = time
 }
 
+// LimitDrains restricts passive drain to given objects, from their approach (or the end of the previous one) to their end.
+// Time spent on other objects doesn't drain. It has to be called after CalculateRate.
+func (hp *HealthProcessor) LimitDrains(objs []objects.IHitObject) {
+	var drains []drain
+
+	lastEnd := int64(math.MinInt64)
+
+	for _, o := range objs {
+		start := mutils.Max(lastEnd, int64(o.GetStartTime()-hp.diff.Preempt))
+		end := int64(o.GetEndTime())
+
+		for _, d := range hp.drains {
+			if dStart, dEnd := mutils.Max(d.start, start), mutils.Min(d.end, end); dStart < dEnd {
+				drains = append(drains, drain{dStart, dEnd})
+			}
+		}
+
+		lastEnd = end
+	}
+
+	hp.drains = drains
+}
+
 func (hp *HealthProcessor) AddFailListener(listener FailListener) {
 	hp.failListeners = append(hp.failListeners, listener)
 }
+
+// SimulateSS does a dry run of a perfect play using calculated drain periods and returns the lowest health reached.
+// It has to be called after CalculateRate.
+func (hp *HealthProcessor) SimulateSS() float64 {
+	playing := hp.playing
+	hp.playing = false
+
+	hp.ResetHp()
+
+	lowestHp := hp.Health
+
+	scale := 1.0
+	if hp.diff.CheckModActive(difficulty.HalfTime) {
+		scale = 0.75
+	}
+
+	lastTime := int64(hp.beatMap.HitObjects[0].GetStartTime()) - int64(hp.diff.Preempt)
+
+	for i, o := range hp.beatMap.HitObjects {
+		hp.Increase(-hp.PassiveDrain*float64(hp.drainTimeBetween(lastTime, int64(o.GetStartTime())))*scale, false)
+
+		objectScale := scale
+		if _, ok := o.(*objects.Spinner); ok && hp.lowerSpinnerDrain {
+			objectScale *= 0.25
+		}
+
+		hp.Increase(-hp.PassiveDrain*float64(hp.drainTimeBetween(int64(o.GetStartTime()), int64(o.GetEndTime())))*objectScale, false)
+
+		lastTime = int64(o.GetEndTime())
+
+		lowestHp = math.Min(lowestHp, hp.Health)
+
+		if s, ok := o.(*objects.Slider); ok {
+			for j := 0; j < len(s.TickReverse)+1; j++ {
+				hp.AddResult(SliderRepeat)
+			}
+
+			for j := 0; j < len(s.TickPoints); j++ {
+				hp.AddResult(SliderPoint)
+			}
+		} else if s, ok := o.(*objects.Spinner); ok {
+			requirement := int((s.GetEndTime() - s.GetStartTime()) / 1000 * hp.diff.SpinnerRatio)
+			for j := 0; j < requirement; j++ {
+				hp.AddResult(SpinnerSpin)
+			}
+		}
+
+		if i == len(hp.beatMap.HitObjects)-1 || hp.beatMap.HitObjects[i+1].IsNewCombo() {
+			hp.AddResult(Hit300g)
+		} else {
+			hp.AddResult(Hit300)
+		}
+	}
+
+	hp.ResetHp()
+	hp.playing = playing
+
+	return lowestHp
+}
+
+// AdjustForSS lowers passive drain until a perfect play doesn't fail. Returns false if it wasn't possible.
+func (hp *HealthProcessor) AdjustForSS() bool {
+	for i := 0; i < 100; i++ {
+		if hp.SimulateSS() > 0 {
+			return true
+		}
+
+		hp.PassiveDrain *= 0.9
+	}
+
+	return hp.SimulateSS() > 0
+}
+
+func (hp *HealthProcessor) drainTimeBetween(start, end int64) int64 {
+	total := int64(0)
+
+	for _, d := range hp.drains {
+		s := mutils.Max(start, d.start)
+		e := mutils.Min(end, d.end)
+
+		if e > s {
+			total += e - s
+		}
+	}
+
+	return total
+}
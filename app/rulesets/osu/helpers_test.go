@@ -0,0 +1,161 @@
+package osu
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap"
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+	"github.com/wieku/danser-go/app/graphics"
+	"github.com/wieku/danser-go/app/settings"
+)
+
+// testClickLength is how long test players hold a key after clicking, in ms
+const testClickLength = 30
+
+func TestMain(m *testing.M) {
+	dir, err := filepath.Abs("testdata")
+	if err != nil {
+		panic(err)
+	}
+
+	settings.General.OsuSongsDir = dir
+
+	log.SetOutput(io.Discard)
+
+	os.Exit(m.Run())
+}
+
+// loadTestMap parses a beatmap from testdata/maps
+func loadTestMap(t testing.TB, file string) *beatmap.BeatMap {
+	t.Helper()
+
+	beatMap := beatmap.NewBeatMap()
+	beatMap.Dir = "maps"
+	beatMap.File = file
+
+	if err := beatmap.ParseBeatMap(beatMap); err != nil {
+		t.Fatalf("failed to parse %s: %s", file, err)
+	}
+
+	beatmap.ParseObjects(beatMap, false, false)
+
+	return beatMap
+}
+
+// newTestRuleset creates a ruleset with a single replay cursor that clicks every object offset ms after its start,
+// alternating keys and aiming perfectly. It has to be run with RunToEnd or RunRange using 1ms steps.
+func newTestRuleset(t *testing.T, beatMap *beatmap.BeatMap, mods difficulty.Modifier, offset int64) (*OsuRuleSet, *graphics.Cursor) {
+	t.Helper()
+
+	ruleset, cursors := newScriptedRuleset(t, beatMap, testPlayer{name: "test", mods: mods, script: clickAll(offset)})
+
+	return ruleset, cursors[0]
+}
+
+// clickScript returns when a test player clicks the object with given number, relative to its start.
+// Objects for which click is false are never clicked, so they are missed.
+type clickScript func(number int) (offset int64, click bool)
+
+// clickAll clicks every object offset ms after its start
+func clickAll(offset int64) clickScript {
+	return func(int) (int64, bool) {
+		return offset, true
+	}
+}
+
+// skipObjects clicks every object offset ms after its start, except the skipped ones
+func skipObjects(offset int64, skipped ...int) clickScript {
+	return func(number int) (int64, bool) {
+		for _, s := range skipped {
+			if s == number {
+				return 0, false
+			}
+		}
+
+		return offset, true
+	}
+}
+
+type testPlayer struct {
+	name   string
+	mods   difficulty.Modifier
+	script clickScript
+}
+
+// newScriptedRuleset creates a ruleset with a replay cursor for every player. Cursors aim perfectly at object starts
+// and click according to player's script, alternating keys. It has to be run with RunToEnd or RunRange using 1ms steps.
+func newScriptedRuleset(t testing.TB, beatMap *beatmap.BeatMap, players ...testPlayer) (*OsuRuleSet, []*graphics.Cursor) {
+	t.Helper()
+
+	cursors := make([]*graphics.Cursor, len(players))
+	mods := make([]difficulty.Modifier, len(players))
+
+	for i, p := range players {
+		cursors[i] = graphics.NewHeadlessCursor()
+		cursors[i].Name = p.name
+		cursors[i].IsReplay = true
+
+		mods[i] = p.mods
+	}
+
+	ruleset := NewOsuRuleset(beatMap, cursors, mods)
+
+	next := make([]int, len(players))
+	releaseTime := make([]int64, len(players))
+	started := false
+
+	ruleset.SetStepListener(func(time int64) {
+		// objects skipped by RunRange are never clicked
+		if !started {
+			started = true
+
+			if len(ruleset.queue) > 0 {
+				for i := range next {
+					next[i] = int(ruleset.queue[0].GetNumber())
+				}
+			}
+		}
+
+		for i, cursor := range cursors {
+			if time >= releaseTime[i] {
+				cursor.LeftButton = false
+				cursor.RightButton = false
+			}
+
+			if next[i] >= len(beatMap.HitObjects) {
+				continue
+			}
+
+			obj := beatMap.HitObjects[next[i]]
+
+			cursor.SetPos(obj.GetStackedStartPositionMod(mods[i]))
+
+			offset, click := players[i].script(next[i])
+
+			if !click {
+				if time >= int64(obj.GetStartTime()) {
+					next[i]++
+				}
+
+				continue
+			}
+
+			if time == int64(obj.GetStartTime())+offset {
+				if next[i]%2 == 0 {
+					cursor.LeftButton = true
+				} else {
+					cursor.RightButton = true
+				}
+
+				releaseTime[i] = time + testClickLength
+				next[i]++
+			}
+		}
+	})
+
+	return ruleset, cursors
+}
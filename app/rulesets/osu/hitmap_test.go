@@ -0,0 +1,34 @@
+package osu
+
+import (
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+)
+
+func TestGetHitMap(t *testing.T) {
+	beatMap := loadTestMap(t, "circles.osu")
+
+	missed := []int{3, 17}
+
+	ruleset, cursors := newScriptedRuleset(t, beatMap, testPlayer{name: "test", mods: difficulty.None, script: skipObjects(0, missed...)})
+	ruleset.RunToEnd(1)
+
+	hitMap := ruleset.GetHitMap(cursors[0])
+
+	if len(hitMap) != len(beatMap.HitObjects) {
+		t.Fatalf("expected %d entries, got %d", len(beatMap.HitObjects), len(hitMap))
+	}
+
+	for i, hit := range hitMap {
+		expected := i != missed[0] && i != missed[1]
+
+		if hit != expected {
+			t.Errorf("object %d: expected hit %t, got %t", i, expected, hit)
+		}
+	}
+
+	if misses := ruleset.GetScore(cursors[0]).CountMiss; misses != uint(len(missed)) {
+		t.Errorf("expected %d misses, got %d", len(missed), misses)
+	}
+}
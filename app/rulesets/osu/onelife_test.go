@@ -0,0 +1,98 @@
+package osu
+
+import (
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+	"github.com/wieku/danser-go/app/settings"
+)
+
+// setOneLife enables 1-life mode with given triggers for the duration of the test
+func setOneLife(t *testing.T, failOnSliderBreak, failOn50 bool) {
+	oneLife := *settings.Gameplay.OneLife
+
+	settings.Gameplay.OneLife.Enabled = true
+	settings.Gameplay.OneLife.FailOnSliderBreak = failOnSliderBreak
+	settings.Gameplay.OneLife.FailOn50 = failOn50
+
+	t.Cleanup(func() { *settings.Gameplay.OneLife = oneLife })
+}
+
+func TestIsOneLifeFail(t *testing.T) {
+	type judgement struct {
+		name        string
+		result      HitResult
+		comboResult ComboResult
+	}
+
+	miss := judgement{"miss", Miss, Reset}
+	sliderBreak := judgement{"slider break", SliderMiss, Reset}
+	hit50 := judgement{"50", Hit50, Increase}
+	hit100 := judgement{"100", Hit100, Increase}
+	hit300 := judgement{"300", Hit300, Increase}
+
+	judgements := []judgement{miss, sliderBreak, hit50, hit100, hit300}
+
+	tests := []struct {
+		failOnSliderBreak bool
+		failOn50          bool
+		fails             []judgement
+	}{
+		{false, false, []judgement{miss}},
+		{true, false, []judgement{miss, sliderBreak}},
+		{false, true, []judgement{miss, hit50}},
+		{true, true, []judgement{miss, sliderBreak, hit50}},
+	}
+
+	for _, tt := range tests {
+		setOneLife(t, tt.failOnSliderBreak, tt.failOn50)
+
+		for _, j := range judgements {
+			expected := false
+
+			for _, f := range tt.fails {
+				if f == j {
+					expected = true
+				}
+			}
+
+			if fail := isOneLifeFail(j.result, j.comboResult); fail != expected {
+				t.Errorf("slider breaks: %t, 50s: %t: expected fail on %s to be %t, got %t", tt.failOnSliderBreak, tt.failOn50, j.name, expected, fail)
+			}
+		}
+	}
+}
+
+func TestOneLifeRun(t *testing.T) {
+	ignoreFails := settings.Gameplay.IgnoreFailsInReplays
+	settings.Gameplay.IgnoreFailsInReplays = false
+
+	t.Cleanup(func() { settings.Gameplay.IgnoreFailsInReplays = ignoreFails })
+
+	tests := []struct {
+		name     string
+		failOn50 bool
+		script   clickScript
+		failed   bool
+	}{
+		{"SS", true, clickAll(0), false},
+		{"miss", false, skipObjects(0, 5), true},
+		// circles.osu has OD8, 100ms late is a 50
+		{"50s allowed", false, clickAll(100), false},
+		{"50s fail", true, clickAll(100), true},
+	}
+
+	for _, tt := range tests {
+		setOneLife(t, false, tt.failOn50)
+
+		beatMap := loadTestMap(t, "circles.osu")
+
+		// NoFail keeps HP from failing the run, 1-life fails are forced through it
+		ruleset, cursors := newScriptedRuleset(t, beatMap, testPlayer{name: "test", mods: difficulty.NoFail, script: tt.script})
+		ruleset.RunToEnd(1)
+
+		if failed := ruleset.cursors[cursors[0]].failed; failed != tt.failed {
+			t.Errorf("%s: expected failed to be %t, got %t", tt.name, tt.failed, failed)
+		}
+	}
+}
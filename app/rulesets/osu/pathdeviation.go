@@ -0,0 +1,60 @@
+package osu
+
+import (
+	"math"
+
+	"github.com/wieku/danser-go/app/beatmap/objects"
+	"github.com/wieku/danser-go/app/graphics"
+)
+
+type pathDeviation struct {
+	sum   float64
+	count int
+}
+
+// samplePathDeviation measures the distance between cursor and the ideal position of every object that should be
+// followed at given time: circles from Hit50 before until they're hit, sliders for their whole duration
+func (set *OsuRuleSet) samplePathDeviation(subSet *subSet, time int64) {
+	player := subSet.player
+
+	for _, o := range set.processed {
+		if o.IsHit(player) {
+			continue
+		}
+
+		obj := set.beatMap.HitObjects[o.GetNumber()]
+
+		if _, ok := obj.(*objects.Spinner); ok {
+			continue
+		}
+
+		if float64(time) < obj.GetStartTime()-float64(player.diff.Hit50) || float64(time) > obj.GetEndTime() {
+			continue
+		}
+
+		ideal := obj.GetStackedPositionAtMod(float64(time), player.diff.Mods)
+
+		deviation := &subSet.deviations[o.GetNumber()]
+		deviation.sum += float64(player.cursor.RawPosition.Dst(ideal))
+		deviation.count++
+	}
+}
+
+// GetPathDeviation returns average distance in osu!pixels between cursor and the ideal path for each object:
+// circle's center from Hit50 before the hit, slider's ball position during the slider.
+// Spinners and objects without samples (e.g. not reached yet) have NaN.
+func (set *OsuRuleSet) GetPathDeviation(cursor *graphics.Cursor) []float64 {
+	deviations := set.cursors[cursor].deviations
+
+	result := make([]float64, len(deviations))
+
+	for i, d := range deviations {
+		if d.count == 0 {
+			result[i] = math.NaN()
+		} else {
+			result[i] = d.sum / float64(d.count)
+		}
+	}
+
+	return result
+}
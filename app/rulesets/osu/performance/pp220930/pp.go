@@ -23,10 +23,22 @@ type PPv2Results struct {
 	Aim, Speed, Acc, Flashlight, Total float64
 }
 
+// AimTapRatio returns aim pp divided by speed pp, above 1 means map is aim-heavy, 0 if there's no speed pp
+func (r PPv2Results) AimTapRatio() float64 {
+	if r.Speed <= 0 {
+		return 0
+	}
+
+	return r.Aim / r.Speed
+}
+
 // PPv2 : structure to store ppv2 values
 type PPv2 struct {
 	Results PPv2Results
 
+	// NoComboScaling disables classic combo based scaling of aim, speed and flashlight values, like in lazer
+	NoComboScaling bool
+
 	attribs Attributes
 
 	experimental bool
@@ -319,7 +331,7 @@ func (pp *PPv2) calculateMissPenalty(missCount, difficultStrainCount float64) fl
 }
 
 func (pp *PPv2) getComboScalingFactor() float64 {
-	if pp.attribs.MaxCombo <= 0 {
+	if pp.NoComboScaling || pp.attribs.MaxCombo <= 0 {
 		return 1.0
 	} else {
 		return math.Min(math.Pow(float64(pp.scoreMaxCombo), 0.8)/math.Pow(float64(pp.attribs.MaxCombo), 0.8), 1.0)
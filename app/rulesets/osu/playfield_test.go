@@ -0,0 +1,41 @@
+package osu
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+)
+
+func TestGetPlayfieldTransform(t *testing.T) {
+	beatMap := loadTestMap(t, "circles.osu")
+
+	ruleset, cursors := newScriptedRuleset(t, beatMap,
+		testPlayer{name: "NM", mods: difficulty.None, script: clickAll(0)},
+		testPlayer{name: "HR", mods: difficulty.HardRock, script: clickAll(0)},
+	)
+
+	if transform := ruleset.GetPlayfieldTransform(cursors[0]); transform != mgl32.Ident4() {
+		t.Errorf("expected identity without mods, got %v", transform)
+	}
+
+	hr := ruleset.GetPlayfieldTransform(cursors[1])
+
+	if flip := mgl32.Translate3D(0, 384, 0).Mul4(mgl32.Scale3D(1, -1, 1)); hr != flip {
+		t.Errorf("expected vertical flip with HR, got %v", hr)
+	}
+
+	if roundTrip := hr.Mul4(hr); !roundTrip.ApproxEqual(mgl32.Ident4()) {
+		t.Errorf("flipping twice should give identity, got %v", roundTrip)
+	}
+
+	// transformed positions have to match positions objects use with HR
+	for i, obj := range beatMap.HitObjects {
+		pos := obj.GetStackedStartPosition()
+		expected := obj.GetStackedStartPositionMod(difficulty.HardRock)
+
+		if p := hr.Mul4x1(mgl32.Vec4{pos.X, pos.Y, 0, 1}); !mgl32.FloatEqual(p.X(), expected.X) || !mgl32.FloatEqual(p.Y(), expected.Y) {
+			t.Errorf("object %d: expected %.0fx%.0f, got %.0fx%.0f", i, expected.X, expected.Y, p.X(), p.Y())
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package osu
+
+import (
+	"github.com/wieku/danser-go/app/beatmap"
+	"github.com/wieku/danser-go/app/beatmap/objects"
+	"github.com/wieku/danser-go/app/settings"
+)
+
+// passesPracticeFilter reports whether the object at given index should be judged with the current practice filter
+func passesPracticeFilter(beatMap *beatmap.BeatMap, index int) bool {
+	obj := beatMap.HitObjects[index]
+
+	switch settings.Gameplay.Practice.Filter {
+	case "Sliders":
+		return obj.GetType() == objects.SLIDER
+	case "Jumps":
+		return obj.GetType() != objects.SPINNER && getSpacing(beatMap, index) >= settings.Gameplay.Practice.SpacingThreshold
+	case "Streams":
+		return obj.GetType() != objects.SPINNER && getSpacing(beatMap, index) < settings.Gameplay.Practice.SpacingThreshold
+	}
+
+	return true
+}
+
+// getSpacing returns the distance between the end of the previous object and the start of the object at given index.
+// First objects and objects after spinners have no meaningful spacing so 0 is returned.
+func getSpacing(beatMap *beatmap.BeatMap, index int) float64 {
+	if index == 0 {
+		return 0
+	}
+
+	prev := beatMap.HitObjects[index-1]
+	if prev.GetType() == objects.SPINNER {
+		return 0
+	}
+
+	return float64(prev.GetStackedEndPosition().Dst(beatMap.HitObjects[index].GetStackedStartPosition()))
+}
@@ -0,0 +1,115 @@
+package osu
+
+import (
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+	"github.com/wieku/danser-go/app/settings"
+)
+
+// setPracticeFilter sets Gameplay.Practice.Filter for the duration of the test
+func setPracticeFilter(t *testing.T, filter string) {
+	filterBefore := settings.Gameplay.Practice.Filter
+	settings.Gameplay.Practice.Filter = filter
+
+	t.Cleanup(func() { settings.Gameplay.Practice.Filter = filterBefore })
+}
+
+func TestPracticeBookkeeping(t *testing.T) {
+	setPracticeFilter(t, "Jumps")
+
+	// mixed.osu has 9 jumps (objects 1-9) followed by 10 stream objects
+	beatMap := loadTestMap(t, "mixed.osu")
+
+	ruleset, cursor := newTestRuleset(t, beatMap, difficulty.None, 0)
+	ruleset.RunToEnd(1)
+
+	score := ruleset.GetScore(cursor)
+
+	if score.Count300 != 9 || score.Combo != 9 {
+		t.Fatalf("expected 9 300s and 9x combo, got %d 300s and %dx combo", score.Count300, score.Combo)
+	}
+
+	if !score.PerfectCombo {
+		t.Error("perfect combo not detected in practice mode")
+	}
+
+	if score.PP != 0 {
+		t.Errorf("expected no pp in practice mode, got %.2f", score.PP)
+	}
+}
+
+func TestLimitDrains(t *testing.T) {
+	beatMap := loadTestMap(t, "mixed.osu")
+
+	hp := NewHealthProcessor(beatMap, difficulty.NewDifficulty(5, 4, 8, 9), true)
+	hp.CalculateRate()
+
+	// keep only the jumps, they end at 3700
+	hp.LimitDrains(beatMap.HitObjects[1:10])
+
+	hp.Update(3000)
+	before := hp.Health
+
+	hp.Update(3500)
+
+	if hp.Health >= before {
+		t.Errorf("health didn't drain during kept objects: %.2f -> %.2f", before, hp.Health)
+	}
+
+	hp.Update(4000)
+	before = hp.Health
+
+	hp.Update(6700)
+
+	if hp.Health != before {
+		t.Errorf("health drained during filtered objects: %.2f -> %.2f", before, hp.Health)
+	}
+}
+
+func TestPracticeFilterRetention(t *testing.T) {
+	beatMap := loadTestMap(t, "mixed.osu")
+
+	all := make([]int, len(beatMap.HitObjects))
+	for i := range all {
+		all[i] = i
+	}
+
+	// the first object has no previous one, so it counts as a stream
+	streams := append([]int{0}, all[10:]...)
+	jumps := all[1:10]
+
+	tests := []struct {
+		filter string
+		kept   []int
+	}{
+		{"All", all},
+		{"Jumps", jumps},
+		{"Streams", streams},
+		{"Sliders", nil},
+	}
+
+	for _, tt := range tests {
+		setPracticeFilter(t, tt.filter)
+
+		var kept []int
+
+		for i := range beatMap.HitObjects {
+			if passesPracticeFilter(beatMap, i) {
+				kept = append(kept, i)
+			}
+		}
+
+		if len(kept) != len(tt.kept) {
+			t.Errorf("%s: expected objects %v, got %v", tt.filter, tt.kept, kept)
+			continue
+		}
+
+		for i := range kept {
+			if kept[i] != tt.kept[i] {
+				t.Errorf("%s: expected objects %v, got %v", tt.filter, tt.kept, kept)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package osu
+
+import (
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+)
+
+func TestGetRank(t *testing.T) {
+	beatMap := loadTestMap(t, "circles.osu")
+
+	// names are ordered against scores, so only the score decides
+	ruleset, cursors := newScriptedRuleset(t, beatMap,
+		testPlayer{name: "carol", mods: difficulty.None, script: skipObjects(0, 5, 20)},
+		testPlayer{name: "bob", mods: difficulty.None, script: skipObjects(0, 5)},
+		testPlayer{name: "alice", mods: difficulty.None, script: clickAll(0)},
+	)
+
+	ruleset.RunToEnd(1)
+
+	for i, cursor := range cursors {
+		if rank := ruleset.GetRank(cursor); rank != len(cursors)-i {
+			t.Errorf("%s: expected rank %d with score %d, got %d", cursor.Name, len(cursors)-i, ruleset.GetScore(cursor).Score, rank)
+		}
+	}
+}
+
+func TestGetRankTie(t *testing.T) {
+	beatMap := loadTestMap(t, "circles.osu")
+
+	ruleset, cursors := newScriptedRuleset(t, beatMap,
+		testPlayer{name: "b", mods: difficulty.None, script: skipObjects(0, 5)},
+		testPlayer{name: "c", mods: difficulty.None, script: clickAll(0)},
+		testPlayer{name: "a", mods: difficulty.None, script: skipObjects(0, 5)},
+	)
+
+	ruleset.RunToEnd(1)
+
+	if scoreA, scoreB := ruleset.GetScore(cursors[2]).Score, ruleset.GetScore(cursors[0]).Score; scoreA != scoreB {
+		t.Fatalf("expected tied scores, got %d and %d", scoreA, scoreB)
+	}
+
+	expected := []int{3, 1, 2}
+
+	for i, cursor := range cursors {
+		if rank := ruleset.GetRank(cursor); rank != expected[i] {
+			t.Errorf("%s: expected rank %d, got %d", cursor.Name, expected[i], rank)
+		}
+	}
+}
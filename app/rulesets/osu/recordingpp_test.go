@@ -0,0 +1,69 @@
+package osu
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+	"github.com/wieku/danser-go/app/settings"
+)
+
+// setRecordingPPInterval enables recording with given Gameplay.RecordingPPInterval for the duration of the test
+func setRecordingPPInterval(tb testing.TB, interval int) {
+	record, previous := settings.RECORD, settings.Gameplay.RecordingPPInterval
+
+	settings.RECORD = true
+	settings.Gameplay.RecordingPPInterval = interval
+
+	tb.Cleanup(func() {
+		settings.RECORD, settings.Gameplay.RecordingPPInterval = record, previous
+	})
+}
+
+// runRecordingPP plays circles.osu with a miss at object 5 and returns final pp with the number of rosu-pp calls made while playing
+func runRecordingPP(tb testing.TB) (float64, uint64) {
+	ruleset, cursors := newScriptedRuleset(tb, loadTestMap(tb, "circles.osu"), testPlayer{name: "test", mods: difficulty.Hidden, script: skipObjects(0, 5)})
+
+	calls := atomic.LoadUint64(&rosuCalls)
+
+	ruleset.RunToEnd(1)
+
+	return ruleset.GetScore(cursors[0]).PP, atomic.LoadUint64(&rosuCalls) - calls
+}
+
+func TestRecordingPPInterval(t *testing.T) {
+	setRecordingPPInterval(t, 1)
+
+	exactPP, exactCalls := runRecordingPP(t)
+
+	// 40 objects: rosu-pp runs after objects 7, 14, 21, 28 and 35, and once more at the end
+	setRecordingPPInterval(t, 7)
+
+	throttledPP, throttledCalls := runRecordingPP(t)
+
+	if exactCalls != 40 || throttledCalls != 6 {
+		t.Errorf("expected 40 rosu-pp calls without throttling and 6 with it, got %d and %d", exactCalls, throttledCalls)
+	}
+
+	if throttledPP != exactPP {
+		t.Errorf("expected final pp to stay exactly %v, got %v", exactPP, throttledPP)
+	}
+}
+
+func BenchmarkRecordingPPInterval(b *testing.B) {
+	for _, interval := range []int{1, 10} {
+		b.Run(fmt.Sprintf("interval-%d", interval), func(b *testing.B) {
+			setRecordingPPInterval(b, interval)
+
+			calls := uint64(0)
+
+			for i := 0; i < b.N; i++ {
+				_, c := runRecordingPP(b)
+				calls += c
+			}
+
+			b.ReportMetric(float64(calls)/float64(b.N), "ffi-calls/op")
+		})
+	}
+}
@@ -14,8 +14,10 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync/atomic"
 	"unsafe"
 
+	"github.com/go-gl/mathgl/mgl32"
 	"github.com/olekukonko/tablewriter"
 	"github.com/wieku/danser-go/app/beatmap"
 	"github.com/wieku/danser-go/app/beatmap/difficulty"
@@ -28,8 +30,14 @@ import (
 	"github.com/wieku/danser-go/framework/math/vector"
 )
 
+// Tolerance2B is osu!'s leeway in ms for objects that overlap in time, Gameplay.Tolerance2B overrides it
 const Tolerance2B = 3
 
+const (
+	desyncCheckObjects = 20
+	desyncThreshold    = 0.5
+)
+
 type ClickAction uint8
 
 const (
@@ -87,6 +95,10 @@ type scoreProcessor interface {
 	ModifyResult(result HitResult, src HitObject) HitResult
 	GetScore() int64
 	GetCombo() int64
+	GetMaxScore() int64
+	Version() int
+	SaveState() processorState
+	LoadState(state processorState)
 }
 
 type Score struct {
@@ -103,14 +115,103 @@ type Score struct {
 	CountMiss    uint
 	CountSB      uint
 	PP           float64
+	PPStable     float64
+	PPLazer      float64
+	UnstableRate float64
 	Mods         difficulty.Modifier
 }
 
+// convertModes maps Gameplay.ConvertMode to game modes passed to rosu-pp
+var convertModes = map[string]uint{
+	"osu":   0,
+	"taiko": 1,
+	"catch": 2,
+	"mania": 3,
+}
+
+// rosuCalls counts rosu-pp (FFI) calculations, see Gameplay.RecordingPPInterval
+var rosuCalls uint64
+
 type rosuPP struct {
 	MapPath     string
+	Mode        uint // Game mode of the beatmap: 0 - osu!, 1 - taiko, 2 - catch, 3 - mania
 	Performance PerformanceResult
 }
 
+type TimelinePoint struct {
+	Time      int64   `json:"time"`
+	Score     int64   `json:"score"`
+	PP        float64 `json:"pp"`
+	Combo     int     `json:"combo"`
+	Accuracy  float64 `json:"accuracy"`
+	Count300  uint    `json:"count300"`
+	Count100  uint    `json:"count100"`
+	Count50   uint    `json:"count50"`
+	CountMiss uint    `json:"countMiss"`
+}
+
+// ChokeInfo describes the first miss of a run if it happened late in the map
+type ChokeInfo struct {
+	Choked    bool
+	Object    int64 // Index of the first missed object
+	ComboLost uint  // Combo broken by that miss
+}
+
+// MapInfo is beatmap's metadata together with difficulty values for beatmap's mods, BPM and length are adjusted by speed changing mods
+type MapInfo struct {
+	Title   string
+	Artist  string
+	Creator string
+	Version string
+
+	MinBPM  float64
+	MaxBPM  float64
+	MainBPM float64
+
+	Length float64 // From the start of the map to the end of the last object, in ms
+
+	Stars float64
+	MaxPP float64
+}
+
+// ObjectState describes an object that is currently processed by the ruleset
+type ObjectState struct {
+	Number    int64
+	FadeTime  int64   // Time from which the object is processed
+	StartTime float64 // Start and end of the object in map time, judgement can happen outside it within hit windows
+	EndTime   float64
+	Hit       map[*graphics.Cursor]bool // Whether the object was already hit/judged for given cursor
+}
+
+// SliderBreak is a combo break that happened without a miss, like dropping a slider or missing its tick
+type SliderBreak struct {
+	Number int64
+	Time   int64
+}
+
+// TimingInfo describes timing active at some point of the map, times are in map time
+type TimingInfo struct {
+	BPM          float64 // Adjusted by speed changing mods, like GetBPMAt
+	BeatLength   float64
+	BeatStart    float64 // Time of the uninherited timing point, beats are counted from it
+	Meter        int
+	SVMultiplier float64
+	Kiai         bool
+}
+
+// chokeThreshold is the fraction of the map that has to be played without misses for the first miss to count as a choke
+const chokeThreshold = 0.9
+
+// hpSmoothingTime is the time constant of GetHPSmooth in ms, it's within 1% of real health ~200ms after a change
+const hpSmoothingTime = 40.0
+
+// RelaxStats tells how Relax auto-taps were resolved, used to validate Relax simulation
+type RelaxStats struct {
+	Hits    uint // Circles and slider heads hit by auto-tap
+	Misses  uint // Circles and slider heads never hit, cursor wasn't over them while auto-tapping
+	OffTaps uint // Auto-taps made while the cursor was outside the object
+}
+
 type ScoreParams struct {
 	Mode          uint
 	Mods          uint
@@ -120,19 +221,27 @@ type ScoreParams struct {
 	PassedObjects uint
 }
 
+// optionU32 marshals an optional FFI value, 0 is treated as not set
+func optionU32(value uint) C.optionu32 {
+	if value == 0 {
+		return C.optionu32{t: C.uint(0), is_some: C.uchar(0)}
+	}
+
+	return C.optionu32{t: C.uint(value), is_some: C.uchar(1)}
+}
+
 type PerformanceResult struct {
 	PP    float64
 	Stars float64
 }
 
 func (calc rosuPP) Calculate(params ScoreParams) PerformanceResult {
+	atomic.AddUint64(&rosuCalls, 1)
+
 	cMapPath := C.CString(calc.MapPath)
 	defer C.free(unsafe.Pointer(cMapPath))
 
-	passedObjects := C.optionu32{t: C.uint(0), is_some: C.uchar(0)}
-	if params.PassedObjects > 0 {
-		passedObjects = C.optionu32{t: C.uint(params.PassedObjects), is_some: C.uchar(1)}
-	}
+	passedObjects := optionU32(params.PassedObjects)
 
 	rawResult := C.calculate_score(
 		cMapPath,
@@ -159,13 +268,67 @@ type subSet struct {
 
 	numObjects uint
 
+	hitMap []bool
+
+	ppDeltas []float64
+	lastPP   float64
+
+	firstMiss      int64
+	firstMissCombo uint
+
+	sliderBreaks []SliderBreak
+
+	lazerAccScore float64
+	lazerAccMax   float64
+
+	replayHash string
+
+	timeOffset int64
+
+	hitErrorSum   float64
+	hitErrorSqSum float64
+	hitErrorCount int
+
+	calibrationOffset int64
+	calibrated        bool
+
+	timeline []TimelinePoint
+
+	presses []keyPress
+
+	deviations []pathDeviation
+
+	relaxStats RelaxStats
+
 	performance *rosuPP
 	ppv2        *pp220930.PPv2
+	ppLazer     *pp220930.PPv2
+
+	// rosu-pp calculation throttling while recording, see Gameplay.RecordingPPInterval
+	ppParams   ScoreParams
+	ppStale    bool
+	ppv2AtRosu float64
+
+	ppPerMiss        float64
+	ppPerMissObjects uint
+
+	extraModPP map[difficulty.Modifier]cachedPP
+
+	smoothHP     float64
+	lastHPUpdate int64
+
+	starsPP220930 float64
+	starsRosuPP   float64
+	convertSSPP   float64
+
+	earlyProblems [desyncCheckObjects]bool // early objects that were missed or shaken, each counted once
+	desyncWarned  bool
 
 	recoveries int
 	failed     bool
 	sdpfFail   bool
 	forceFail  bool
+	truncated  bool
 }
 
 type hitListener func(cursor *graphics.Cursor, time int64, number int64, position vector.Vector2d, result HitResult, comboResult ComboResult, ppResults PerformanceResult, score int64)
@@ -174,13 +337,33 @@ type endListener func(time int64, number int64)
 
 type failListener func(cursor *graphics.Cursor)
 
+type desyncListener func(cursor *graphics.Cursor, ratio float64)
+
+type stepListener func(time int64)
+
 type OsuRuleSet struct {
 	beatMap *beatmap.BeatMap
 	cursors map[*graphics.Cursor]*subSet
 
+	// cursorOrder keeps cursors in the order they were passed to NewOsuRuleset
+	cursorOrder []*graphics.Cursor
+
 	ended bool
 
+	// practice is true if Gameplay.Practice.Filter skips some objects, fails are disabled then
+	practice bool
+
+	// partial is true if only some objects are judged, by practice filter or RunRange. pp isn't calculated then
+	partial bool
+
+	// partialCombo holds max combo after each judged object of a partial run
+	partialCombo []uint
+
 	oppDiffs map[difficulty.Modifier][]pp220930.Attributes
+	ssPP     map[difficulty.Modifier]pp220930.PPv2Results
+
+	// rosuSS caches rosu-pp (FFI) results of a perfect play, keyed by all mods as pp depends on non-difficulty mods too
+	rosuSS map[difficulty.Modifier]PerformanceResult
 
 	queue        []HitObject
 	processed    []HitObject
@@ -188,7 +371,14 @@ type OsuRuleSet struct {
 	endListener  endListener
 	failListener failListener
 
+	subscribers hitSubscribers
+
+	desyncListener desyncListener
+	stepListener   stepListener
+
 	experimentalPP bool
+
+	audioLength float64
 }
 
 func NewOsuRuleset(beatMap *beatmap.BeatMap, cursors []*graphics.Cursor, mods []difficulty.Modifier) *OsuRuleSet {
@@ -197,10 +387,13 @@ func NewOsuRuleset(beatMap *beatmap.BeatMap, cursors []*graphics.Cursor, mods []
 	ruleset := new(OsuRuleSet)
 	ruleset.beatMap = beatMap
 	ruleset.oppDiffs = make(map[difficulty.Modifier][]pp220930.Attributes)
+	ruleset.ssPP = make(map[difficulty.Modifier]pp220930.PPv2Results)
+	ruleset.rosuSS = make(map[difficulty.Modifier]PerformanceResult)
 
 	log.Println("Using pp calc version 2022-09-30: https://osu.ppy.sh/home/news/2022-09-30-changes-to-osu-sr-and-pp")
 
 	ruleset.cursors = make(map[*graphics.Cursor]*subSet)
+	ruleset.cursorOrder = cursors
 
 	diffPlayers := make([]*difficultyPlayer, 0, len(cursors))
 
@@ -215,6 +408,14 @@ func NewOsuRuleset(beatMap *beatmap.BeatMap, cursors []*graphics.Cursor, mods []
 		diff.SetMods(mods[i] | (beatMap.Diff.Mods & difficulty.ScoreV2)) // if beatmap has ScoreV2 mod, force it for all players
 		diff.SetCustomSpeed(beatMap.Diff.CustomSpeed)
 
+		if windows := settings.Gameplay.HitWindows; windows.Enabled {
+			if err := diff.SetHitWindows(windows.Hit300, windows.Hit100, windows.Hit50); err != nil {
+				log.Printf("Ignoring custom hit windows for \"%s\": %s", cursor.Name, err)
+			} else {
+				log.Printf("Using custom hit windows for \"%s\": 300: %dms, 100: %dms, 50: %dms", cursor.Name, windows.Hit300, windows.Hit100, windows.Hit50)
+			}
+		}
+
 		player := &difficultyPlayer{cursor: cursor, diff: diff}
 		diffPlayers = append(diffPlayers, player)
 
@@ -248,12 +449,27 @@ func NewOsuRuleset(beatMap *beatmap.BeatMap, cursors []*graphics.Cursor, mods []
 
 			log.Println("\tAcc:  ", pp.Results.Acc)
 			log.Println("\tTotal:", pp.Results.Total)
+
+			ruleset.ssPP[maskedMods] = pp.Results
 		}
 
 		log.Printf("Calculating HP rates for \"%s\"...", cursor.Name)
 
 		hp := NewHealthProcessor(beatMap, diff, !cursor.OldSpinnerScoring)
 		hp.CalculateRate()
+
+		if hp.SimulateSS() <= 0 {
+			log.Println("\tWarning: HP drain is too high, even an SS would fail!")
+
+			if settings.Gameplay.LowerImpossibleDrain && cursor.IsAutoplay {
+				if hp.AdjustForSS() {
+					log.Println("\tLowered passive drain so autoplay can finish the map")
+				} else {
+					log.Println("\tFailed to lower passive drain enough")
+				}
+			}
+		}
+
 		hp.ResetHp()
 
 		log.Println("\tPassive drain rate:", hp.PassiveDrain/2*1000)
@@ -279,23 +495,90 @@ func NewOsuRuleset(beatMap *beatmap.BeatMap, cursors []*graphics.Cursor, mods []
 
 		sc.Init(beatMap, player)
 
+		attribs := ruleset.oppDiffs[maskedMods]
+
+		mode := uint(beatMap.Mode)
+		if mode == 0 {
+			mode = convertModes[settings.Gameplay.ConvertMode]
+		}
+
+		performance := &rosuPP{
+			MapPath: filepath.Join(settings.General.GetSongsDir(), beatMap.Dir, beatMap.File),
+			Mode:    mode,
+		}
+
+		ssResult, ok := ruleset.rosuSS[diff.Mods]
+		if !ok {
+			ssResult = performance.Calculate(ScoreParams{
+				Mode:     performance.Mode,
+				Mods:     uint(diff.Mods),
+				MaxCombo: uint(attribs[len(attribs)-1].MaxCombo),
+				Accuracy: 100,
+			})
+
+			ruleset.rosuSS[diff.Mods] = ssResult
+		}
+
+		rosuStars := ssResult.Stars
+
+		// Objects are judged as osu! ones, their results don't describe a play in other modes, so only stars are kept
+		convertSSPP := 0.0
+
+		if performance.Mode != 0 {
+			performance.Performance.Stars = ssResult.Stars
+			convertSSPP = ssResult.PP
+
+			log.Printf("SS PP for \"%s\" in converted mode %d: %.2f (rosu-pp), not awarded to the score", cursor.Name, performance.Mode, convertSSPP)
+		}
+
+		log.Printf("Star rating for \"%s\": %.2f (pp220930), %.2f (rosu-pp)", cursor.Name, attribs[len(attribs)-1].Total, rosuStars)
+
 		ruleset.cursors[cursor] = &subSet{
 			player: player,
 			score: &Score{
 				Accuracy: 100,
 				Mods:     mods[i],
 			},
-			performance: &rosuPP{
-				MapPath: filepath.Join(settings.General.GetSongsDir(), beatMap.Dir, beatMap.File),
-			},
+			performance:    performance,
 			ppv2:           &pp220930.PPv2{},
+			ppLazer:        &pp220930.PPv2{NoComboScaling: true},
+			starsPP220930:  attribs[len(attribs)-1].Total,
+			starsRosuPP:    rosuStars,
+			convertSSPP:    convertSSPP,
+			hitMap:         make([]bool, len(beatMap.HitObjects)),
+			ppDeltas:       make([]float64, len(beatMap.HitObjects)),
+			deviations:     make([]pathDeviation, len(beatMap.HitObjects)),
+			extraModPP:     make(map[difficulty.Modifier]cachedPP),
+			firstMiss:      -1,
 			hp:             hp,
+			smoothHP:       hp.Health / MaxHp,
+			lastHPUpdate:   math.MinInt64,
 			recoveries:     recoveries,
 			scoreProcessor: sc,
 		}
+
+		if cursor.IsPlayer && settings.Gameplay.InputLatency != 0 {
+			ruleset.cursors[cursor].timeOffset = -settings.Gameplay.InputLatency
+
+			log.Printf("Compensating %dms of input latency for \"%s\"", settings.Gameplay.InputLatency, cursor.Name)
+		}
 	}
 
-	for _, obj := range beatMap.HitObjects {
+	if settings.Gameplay.Practice.Filter != "All" {
+		ruleset.practice = true
+
+		log.Println("Using practice filter:", settings.Gameplay.Practice.Filter, "- pp and fails are disabled")
+	}
+
+	var kept []objects.IHitObject
+
+	for i, obj := range beatMap.HitObjects {
+		if !passesPracticeFilter(beatMap, i) {
+			continue
+		}
+
+		kept = append(kept, obj)
+
 		if circle, ok := obj.(*objects.Circle); ok {
 			rCircle := new(Circle)
 			rCircle.Init(ruleset, circle, diffPlayers)
@@ -315,6 +598,14 @@ func NewOsuRuleset(beatMap *beatmap.BeatMap, cursors []*graphics.Cursor, mods []
 		}
 	}
 
+	if ruleset.practice {
+		ruleset.setPartial()
+
+		for _, subSet := range ruleset.cursors {
+			subSet.hp.LimitDrains(kept)
+		}
+	}
+
 	return ruleset
 }
 
@@ -352,21 +643,29 @@ func (set *OsuRuleSet) Update(time int64) {
 
 	for _, subSet := range set.cursors {
 		subSet.hp.Update(time)
+		subSet.updateSmoothHP(time)
 	}
 
 	if len(set.queue) == 0 && len(set.processed) == 0 && !set.ended {
 		cs := make([]*graphics.Cursor, 0)
-		for c := range set.cursors {
+		for c, subSet := range set.cursors {
+			subSet.finishPP()
+
 			cs = append(cs, c)
 		}
 
 		sort.Slice(cs, func(i, j int) bool {
-			return set.cursors[cs[i]].scoreProcessor.GetScore() > set.cursors[cs[j]].scoreProcessor.GetScore()
+			return set.ranksAbove(cs[i], cs[j])
 		})
 
 		tableString := &strings.Builder{}
 		table := tablewriter.NewWriter(tableString)
-		table.SetHeader([]string{"#", "Player", "Score", "Accuracy", "Grade", "300", "100", "50", "Miss", "Combo", "Max Combo", "Mods", "PP"})
+		header := []string{"#", "Player", "Score", "Accuracy", "Grade", "300", "100", "50", "Miss", "Combo", "Max Combo", "UR", "Mods", "Stars", "PP"}
+		if settings.Gameplay.CompareComboScaling {
+			header = append(header, "PP (stable)", "PP (lazer)")
+		}
+
+		table.SetHeader(header)
 
 		for i, c := range cs {
 			var data []string
@@ -381,8 +680,16 @@ func (set *OsuRuleSet) Update(time int64) {
 			data = append(data, utils.Humanize(set.cursors[c].score.CountMiss))
 			data = append(data, utils.Humanize(set.cursors[c].scoreProcessor.GetCombo()))
 			data = append(data, utils.Humanize(set.cursors[c].score.Combo))
+			data = append(data, fmt.Sprintf("%.2f", set.cursors[c].score.UnstableRate))
 			data = append(data, set.cursors[c].player.diff.GetModString())
-			data = append(data, fmt.Sprintf("%.2f", set.cursors[c].performance.Performance.PP))
+			data = append(data, fmt.Sprintf("%.2f", set.GetStars(c)))
+			data = append(data, fmt.Sprintf("%.2f", set.cursors[c].score.PP))
+
+			if settings.Gameplay.CompareComboScaling {
+				data = append(data, fmt.Sprintf("%.2f", set.cursors[c].score.PPStable))
+				data = append(data, fmt.Sprintf("%.2f", set.cursors[c].score.PPLazer))
+			}
+
 			table.Append(data)
 		}
 
@@ -392,13 +699,204 @@ func (set *OsuRuleSet) Update(time int64) {
 			log.Println(s)
 		}
 
+		for _, c := range cs {
+			set.logTimingBias(c)
+
+			if choke := set.GetChokeInfo(c); choke.Choked {
+				log.Printf("%s choked on object #%d, lost %dx combo", c.Name, choke.Object, choke.ComboLost)
+			}
+
+			if index, pp := set.GetTopPPObject(c); index >= 0 {
+				log.Printf("%s biggest play: object #%d, %+.2fpp", c.Name, index, pp)
+			}
+
+			log.Printf("%s pp efficiency: %.2fpp/*", c.Name, set.GetPPEfficiency(c))
+			log.Printf("%s aim/tap ratio: %.2f", c.Name, set.GetAimTapRatio(set.cursors[c].player.diff.Mods))
+
+			if set.cursors[c].player.diff.Mods.Active(difficulty.Relax) {
+				rs := set.cursors[c].relaxStats
+				log.Printf("%s Relax auto-taps: %d hit, %d missed, %d off-object", c.Name, rs.Hits, rs.Misses, rs.OffTaps)
+			}
+		}
+
 		set.ended = true
 	}
 }
 
+// recordLazerAccuracy accumulates lazer-like accuracy, where slider heads, ticks and repeats are worth 30 and slider ends 150 on top of object judgements
+func (subSet *subSet) recordLazerAccuracy(src HitObject, result HitResult) {
+	if result&SliderHits > 0 || result == SliderMiss {
+		slider, ok := src.(*Slider)
+		if !ok {
+			return
+		}
+
+		weight := slider.getComponentWeight(subSet.player, result)
+
+		subSet.lazerAccMax += weight
+
+		if result != SliderMiss {
+			subSet.lazerAccScore += weight
+		}
+
+		return
+	}
+
+	if result&BaseHitsM > 0 {
+		subSet.lazerAccMax += 300
+		subSet.lazerAccScore += float64((result & BaseHits).ScoreValue())
+	}
+}
+
+// lazerAccuracy returns accuracy in percent accumulated by recordLazerAccuracy
+func (subSet *subSet) lazerAccuracy() float64 {
+	if subSet.lazerAccMax == 0 {
+		return 100
+	}
+
+	return 100 * subSet.lazerAccScore / subSet.lazerAccMax
+}
+
+// setPartial disables pp and calculates max combo limits for objects left in the queue
+func (set *OsuRuleSet) setPartial() {
+	set.partial = true
+	set.partialCombo = set.partialCombo[:0]
+
+	combo := uint(0)
+
+	for _, obj := range set.queue {
+		if slider, ok := set.beatMap.HitObjects[obj.GetNumber()].(*objects.Slider); ok {
+			combo += uint(len(slider.ScorePoints))
+		}
+
+		combo++
+
+		set.partialCombo = append(set.partialCombo, combo)
+	}
+}
+
+// maxCombo returns the highest combo possible for objects judged so far
+func (set *OsuRuleSet) maxCombo(subSet *subSet) uint {
+	index := mutils.Max(1, subSet.numObjects) - 1
+
+	if set.partial {
+		return set.partialCombo[index]
+	}
+
+	return uint(set.oppDiffs[difficulty.GetDiffMaskedMods(subSet.player.diff.Mods)][index].MaxCombo)
+}
+
+// updatePP calculates pp of objects judged so far
+func (set *OsuRuleSet) updatePP(subSet *subSet) {
+	// Both rosu-pp and pp220930 apply NoFail multiplier (max(0.9, 1-0.02*effective misses)) on their own, pp must not be scaled again here
+	params := ScoreParams{
+		Mode:          subSet.performance.Mode,
+		Mods:          uint(subSet.player.diff.Mods),
+		MaxCombo:      subSet.score.Combo,
+		Accuracy:      subSet.score.Accuracy,
+		MissCount:     subSet.score.CountMiss,
+		PassedObjects: uint(subSet.numObjects),
+	}
+
+	// osu! judgements don't describe a play in other modes, so no pp is awarded, see GetConvertSSPP
+	if subSet.performance.Mode != 0 {
+		return
+	}
+
+	index := mutils.Max(1, subSet.numObjects) - 1
+
+	diff := set.oppDiffs[difficulty.GetDiffMaskedMods(subSet.player.diff.Mods)][index]
+
+	subSet.ppv2.PPv2x(diff, int(subSet.score.Combo), int(subSet.score.Count300), int(subSet.score.Count100), int(subSet.score.Count50), int(subSet.score.CountMiss), subSet.player.diff)
+
+	subSet.ppParams = params
+
+	// While recording, rosu-pp (FFI) can be called only every Nth object. Meanwhile, its last value is scaled
+	// by the change of pp220930 result, so displayed pp is approximate until the next calculation or the map end
+	if interval := settings.Gameplay.RecordingPPInterval; settings.RECORD && interval > 1 && subSet.numObjects%uint(interval) != 0 {
+		subSet.ppStale = true
+	} else {
+		subSet.performance.Performance = subSet.performance.Calculate(params)
+		subSet.ppv2AtRosu = subSet.ppv2.Results.Total
+		subSet.ppStale = false
+
+		log.Printf("%v PP | %v Stars", subSet.performance.Performance.PP, subSet.performance.Performance.Stars)
+	}
+
+	subSet.score.PP = subSet.blendPP()
+
+	if settings.Gameplay.CompareComboScaling {
+		subSet.ppLazer.PPv2x(diff, int(subSet.score.Combo), int(subSet.score.Count300), int(subSet.score.Count100), int(subSet.score.Count50), int(subSet.score.CountMiss), subSet.player.diff)
+
+		subSet.score.PPStable = subSet.performance.Performance.PP
+		subSet.score.PPLazer = subSet.ppLazer.Results.Total
+	}
+}
+
+// blendPP blends pp from rosu-pp (FFI) and pp220930 (pure Go), Gameplay.PPBlend = 1 means FFI only
+func (subSet *subSet) blendPP() float64 {
+	rosu := subSet.performance.Performance.PP
+
+	if subSet.ppStale && subSet.ppv2AtRosu > 0 {
+		rosu *= subSet.ppv2.Results.Total / subSet.ppv2AtRosu
+	}
+
+	blend := settings.Gameplay.PPBlend
+
+	return blend*rosu + (1-blend)*subSet.ppv2.Results.Total
+}
+
+// finishPP calculates exact rosu-pp result if it was skipped for the last judgements
+func (subSet *subSet) finishPP() {
+	if !subSet.ppStale {
+		return
+	}
+
+	subSet.performance.Performance = subSet.performance.Calculate(subSet.ppParams)
+	subSet.ppv2AtRosu = subSet.ppv2.Results.Total
+	subSet.ppStale = false
+
+	subSet.score.PP = subSet.blendPP()
+
+	if settings.Gameplay.CompareComboScaling {
+		subSet.score.PPStable = subSet.performance.Performance.PP
+	}
+}
+
+// addTimelinePoint stores the point, if it's closer than Gameplay.ScoreTimelineInterval to the previous one, previous point is replaced
+func (subSet *subSet) addTimelinePoint(point TimelinePoint) {
+	if n := len(subSet.timeline); n > 1 && point.Time-subSet.timeline[n-2].Time < settings.Gameplay.ScoreTimelineInterval {
+		subSet.timeline[n-1] = point
+		return
+	}
+
+	subSet.timeline = append(subSet.timeline, point)
+}
+
+func (set *OsuRuleSet) logTimingBias(cursor *graphics.Cursor) {
+	if set.cursors[cursor].hitErrorCount == 0 {
+		return
+	}
+
+	bias := set.GetTimingBias(cursor)
+
+	if math.Abs(bias) < math.Max(settings.Gameplay.TimingBiasThreshold, 0.5) {
+		return
+	}
+
+	direction := "late"
+	if bias < 0 {
+		direction = "early"
+	}
+
+	log.Printf("%s is hitting %.0fms %s on average, consider %+.0fms offset", cursor.Name, math.Abs(bias), direction, -bias)
+}
+
 func (set *OsuRuleSet) UpdateClickFor(cursor *graphics.Cursor, time int64) {
 	player := set.cursors[cursor].player
 
+	time += set.cursors[cursor].timeOffset
+
 	player.alreadyStolen = false
 
 	if player.cursor.IsReplayFrame || player.cursor.IsPlayer {
@@ -408,6 +906,9 @@ func (set *OsuRuleSet) UpdateClickFor(cursor *graphics.Cursor, time int64) {
 		player.leftCondE = player.leftCond
 		player.rightCondE = player.rightCond
 
+		set.cursors[cursor].recordPress(time)
+		set.samplePathDeviation(set.cursors[cursor], time)
+
 		if player.buttons.Left != player.cursor.LeftButton || player.buttons.Right != player.cursor.RightButton {
 			player.gameDownState = player.cursor.LeftButton || player.cursor.RightButton
 			player.lastButton2 = player.lastButton
@@ -442,6 +943,8 @@ func (set *OsuRuleSet) UpdateClickFor(cursor *graphics.Cursor, time int64) {
 func (set *OsuRuleSet) UpdateNormalFor(cursor *graphics.Cursor, time int64, processSliderEndsAhead bool) {
 	player := set.cursors[cursor].player
 
+	time += set.cursors[cursor].timeOffset
+
 	wasSliderAlready := false
 
 	if len(set.processed) > 0 {
@@ -472,6 +975,8 @@ func (set *OsuRuleSet) UpdateNormalFor(cursor *graphics.Cursor, time int64, proc
 func (set *OsuRuleSet) UpdatePostFor(cursor *graphics.Cursor, time int64, processSliderEndsAhead bool) {
 	player := set.cursors[cursor].player
 
+	time += set.cursors[cursor].timeOffset
+
 	if len(set.processed) > 0 {
 		for i := 0; i < len(set.processed); i++ {
 			g := set.processed[i]
@@ -485,9 +990,22 @@ func (set *OsuRuleSet) SendResult(time int64, cursor *graphics.Cursor, src HitOb
 	number := src.GetNumber()
 	subSet := set.cursors[cursor]
 
+	if subSet.player.diff.Mods.Active(difficulty.Relax) {
+		set.recordRelax(subSet, src, result)
+	}
+
 	if result == Ignore || result == PositionalMiss {
-		if result == PositionalMiss && set.hitListener != nil && !subSet.player.diff.Mods.Active(difficulty.Relax) {
-			set.hitListener(cursor, time, number, vector.NewVec2f(x, y).Copy64(), result, comboResult, subSet.performance.Performance, subSet.scoreProcessor.GetScore())
+		if result == PositionalMiss && !subSet.player.diff.Mods.Active(difficulty.Relax) {
+			set.notifyHit(HitEvent{
+				Cursor:      cursor,
+				Time:        time,
+				Number:      number,
+				Position:    vector.NewVec2f(x, y).Copy64(),
+				Result:      result,
+				ComboResult: comboResult,
+				PP:          PerformanceResult{PP: subSet.score.PP, Stars: subSet.performance.Performance.Stars},
+				Score:       subSet.scoreProcessor.GetScore(),
+			})
 		}
 
 		return
@@ -506,12 +1024,35 @@ func (set *OsuRuleSet) SendResult(time int64, cursor *graphics.Cursor, src HitOb
 	}
 
 	result = subSet.scoreProcessor.ModifyResult(result, src)
+
+	comboBefore := subSet.scoreProcessor.GetCombo()
+
 	subSet.scoreProcessor.AddResult(result, comboResult)
 
 	subSet.score.Score = subSet.scoreProcessor.GetScore()
 
+	subSet.recordLazerAccuracy(src, result)
+
+	_, isCircle := src.(*Circle)
+	_, isSlider := src.(*Slider)
+
+	if (isCircle && result&BaseHits > 0) || (isSlider && result&SliderStart > 0) {
+		hitError := float64(time) - set.beatMap.HitObjects[number].GetStartTime()
+
+		subSet.hitErrorSum += hitError
+		subSet.hitErrorSqSum += hitError * hitError
+		subSet.hitErrorCount++
+
+		subSet.score.UnstableRate = subSet.unstableRate()
+
+		if !subSet.calibrated && subSet.hitErrorCount == settings.Gameplay.CalibrationObjects {
+			set.calibrate(subSet)
+		}
+	}
+
 	if comboResult == Reset && result != Miss {
 		subSet.score.CountSB++
+		subSet.sliderBreaks = append(subSet.sliderBreaks, SliderBreak{Number: number, Time: time})
 	}
 
 	bResult := result & BaseHitsM
@@ -527,9 +1068,20 @@ func (set *OsuRuleSet) SendResult(time int64, cursor *graphics.Cursor, src HitOb
 		case Hit50:
 			subSet.score.Count50++
 		case Miss:
+			if subSet.score.CountMiss == 0 {
+				subSet.firstMiss = number
+				subSet.firstMissCombo = uint(comboBefore)
+			}
+
 			subSet.score.CountMiss++
 		}
 
+		subSet.hitMap[number] = bResult != Miss
+
+		if bResult == Miss && number < desyncCheckObjects {
+			subSet.earlyProblems[number] = true
+		}
+
 		subSet.numObjects++
 	}
 
@@ -541,51 +1093,32 @@ func (set *OsuRuleSet) SendResult(time int64, cursor *graphics.Cursor, src HitOb
 		subSet.score.Accuracy = 100 * float64(subSet.rawScore) / float64(subSet.numObjects*300)
 	}
 
-	ratio := float64(subSet.score.Count300) / float64(subSet.numObjects)
+	// Grades follow osu! rules in every mode, close to taiko's but only approximate for catch and mania
+	subSet.score.Grade = ComputeGrade(subSet.score.Count300, subSet.score.Count100, subSet.score.Count50, subSet.score.CountMiss, subSet.numObjects, subSet.player.diff.Mods)
 
-	if subSet.score.Count300 == subSet.numObjects {
-		if subSet.player.diff.Mods&(difficulty.Hidden|difficulty.Flashlight) > 0 {
-			subSet.score.Grade = SSH
-		} else {
-			subSet.score.Grade = SS
-		}
-	} else if ratio > 0.9 && float64(subSet.score.Count50)/float64(subSet.numObjects) < 0.01 && subSet.score.CountMiss == 0 {
-		if subSet.player.diff.Mods&(difficulty.Hidden|difficulty.Flashlight) > 0 {
-			subSet.score.Grade = SH
-		} else {
-			subSet.score.Grade = S
-		}
-	} else if ratio > 0.8 && subSet.score.CountMiss == 0 || ratio > 0.9 {
-		subSet.score.Grade = A
-	} else if ratio > 0.7 && subSet.score.CountMiss == 0 || ratio > 0.8 {
-		subSet.score.Grade = B
-	} else if ratio > 0.6 {
-		subSet.score.Grade = _C
-	} else {
-		subSet.score.Grade = D
-	}
+	subSet.score.PerfectCombo = set.maxCombo(subSet) == subSet.score.Combo
 
-	params := ScoreParams{
-		Mode:          0,
-		Mods:          uint(subSet.player.diff.Mods),
-		MaxCombo:      subSet.score.Combo,
-		Accuracy:      subSet.score.Accuracy,
-		MissCount:     subSet.score.CountMiss,
-		PassedObjects: uint(subSet.numObjects),
+	// Both calculators would score the first numObjects objects of the map instead of the judged ones, so pp isn't calculated in partial runs
+	if !set.partial {
+		set.updatePP(subSet)
 	}
 
-	subSet.performance.Performance = subSet.performance.Calculate(params)
-	log.Printf("%v PP | %v Stars", subSet.performance.Performance.PP, subSet.performance.Performance.Stars)
-
-	index := mutils.Max(1, subSet.numObjects) - 1
-
-	diff := set.oppDiffs[difficulty.GetDiffMaskedMods(subSet.player.diff.Mods)][index]
-
-	subSet.score.PerfectCombo = uint(diff.MaxCombo) == subSet.score.Combo
-
-	subSet.ppv2.PPv2x(diff, int(subSet.score.Combo), int(subSet.score.Count300), int(subSet.score.Count100), int(subSet.score.Count50), int(subSet.score.CountMiss), subSet.player.diff)
+	if bResult > 0 {
+		subSet.ppDeltas[number] = subSet.score.PP - subSet.lastPP
+		subSet.lastPP = subSet.score.PP
+	}
 
-	subSet.score.PP = subSet.performance.Performance.PP
+	subSet.addTimelinePoint(TimelinePoint{
+		Time:      time,
+		Score:     subSet.scoreProcessor.GetScore(),
+		PP:        subSet.score.PP,
+		Combo:     int(subSet.scoreProcessor.GetCombo()),
+		Accuracy:  subSet.score.Accuracy,
+		Count300:  subSet.score.Count300,
+		Count100:  subSet.score.Count100,
+		Count50:   subSet.score.Count50,
+		CountMiss: subSet.score.CountMiss,
+	})
 
 	switch result {
 	case Hit100:
@@ -615,7 +1148,8 @@ func (set *OsuRuleSet) SendResult(time int64, cursor *graphics.Cursor, src HitOb
 			}
 		}
 
-		if result&BaseHits > 0 {
+		// Geki/katu are osu!-specific, other modes have their own rules
+		if result&BaseHits > 0 && subSet.performance.Mode == 0 {
 			if subSet.currentKatu == 0 && subSet.currentBad == 0 && allClicked {
 				result |= GekiAddition
 				subSet.score.CountGeki++
@@ -637,8 +1171,24 @@ func (set *OsuRuleSet) SendResult(time int64, cursor *graphics.Cursor, src HitOb
 		subSet.hp.AddResult(result)
 	}
 
-	if set.hitListener != nil {
-		set.hitListener(cursor, time, number, vector.NewVec2f(x, y).Copy64(), result, comboResult, subSet.performance.Performance, subSet.scoreProcessor.GetScore())
+	set.notifyHit(HitEvent{
+		Cursor:      cursor,
+		Time:        time,
+		Number:      number,
+		Position:    vector.NewVec2f(x, y).Copy64(),
+		Result:      result,
+		ComboResult: comboResult,
+		PP:          PerformanceResult{PP: subSet.score.PP, Stars: subSet.performance.Performance.Stars},
+		Score:       subSet.scoreProcessor.GetScore(),
+	})
+
+	if bResult > 0 {
+		set.checkDesync(subSet)
+	}
+
+	if settings.Gameplay.OneLife.Enabled && !subSet.failed && isOneLifeFail(result, comboResult) {
+		subSet.forceFail = true
+		set.failInternal(subSet.player)
 	}
 
 	if len(set.cursors) == 1 && !settings.RECORD {
@@ -662,7 +1212,84 @@ func (set *OsuRuleSet) SendResult(time int64, cursor *graphics.Cursor, src HitOb
 	}
 }
 
+// calibrate applies mean hit error of objects judged so far as time offset for the rest of the map. It changes judgements
+// of the remaining objects, so the score won't match osu!. Hit error statistics start over, so unstable rate and timing
+// bias describe only the calibrated part while GetCalibrationOffset reports the bias before it.
+func (set *OsuRuleSet) calibrate(subSet *subSet) {
+	subSet.calibrationOffset = -int64(math.Round(subSet.hitErrorSum / float64(subSet.hitErrorCount)))
+	subSet.timeOffset += subSet.calibrationOffset
+	subSet.calibrated = true
+
+	subSet.hitErrorSum = 0
+	subSet.hitErrorSqSum = 0
+	subSet.hitErrorCount = 0
+
+	log.Printf("%s calibrated after %d objects, applied %+dms offset", subSet.player.cursor.Name, subSet.hitErrorCount, subSet.calibrationOffset)
+}
+
+func (set *OsuRuleSet) recordRelax(subSet *subSet, src HitObject, result HitResult) {
+	if result == PositionalMiss {
+		subSet.relaxStats.OffTaps++
+		return
+	}
+
+	switch o := src.(type) {
+	case *Circle:
+		if result&BaseHits > 0 {
+			subSet.relaxStats.Hits++
+		} else if result == Miss {
+			subSet.relaxStats.Misses++
+		}
+	case *Slider:
+		if result == SliderStart {
+			subSet.relaxStats.Hits++
+		} else if result == SliderMiss && !o.IsStartHit(subSet.player) {
+			subSet.relaxStats.Misses++
+		}
+	}
+}
+
+// checkDesync warns once the early objects were judged if an unusual amount of them were missed or shaken,
+// which usually means that the replay was made on a different version of the map or with different speed
+func (set *OsuRuleSet) checkDesync(subSet *subSet) {
+	if subSet.desyncWarned || !subSet.player.cursor.IsReplay {
+		return
+	}
+
+	toCheck := mutils.Min(desyncCheckObjects, len(set.beatMap.HitObjects))
+
+	if int(subSet.numObjects) < toCheck {
+		return
+	}
+
+	subSet.desyncWarned = true
+
+	problems := 0
+
+	for _, problem := range subSet.earlyProblems {
+		if problem {
+			problems++
+		}
+	}
+
+	ratio := float64(problems) / float64(toCheck)
+
+	if ratio >= desyncThreshold && set.desyncListener != nil {
+		set.desyncListener(subSet.player.cursor, ratio)
+	}
+}
+
 func (set *OsuRuleSet) CanBeHit(time int64, object HitObject, player *difficultyPlayer) ClickAction {
+	action := set.canBeHit(time, object, player)
+
+	if action == Shake && object.GetNumber() < desyncCheckObjects {
+		set.cursors[player.cursor].earlyProblems[object.GetNumber()] = true
+	}
+
+	return action
+}
+
+func (set *OsuRuleSet) canBeHit(time int64, object HitObject, player *difficultyPlayer) ClickAction {
 	if !player.cursor.IsAutoplay && !player.cursor.IsPlayer {
 		if _, ok := object.(*Circle); ok {
 			index := -1
@@ -678,10 +1305,12 @@ func (set *OsuRuleSet) CanBeHit(time int64, object HitObject, player *difficulty
 			}
 		}
 
+		tolerance := float64(mutils.Max(settings.Gameplay.Tolerance2B, 0))
+
 		for _, g := range set.processed {
 			if !g.IsHit(player) {
 				if g.GetNumber() != object.GetNumber() {
-					if set.beatMap.HitObjects[g.GetNumber()].GetEndTime()+Tolerance2B < set.beatMap.HitObjects[object.GetNumber()].GetStartTime() {
+					if set.beatMap.HitObjects[g.GetNumber()].GetEndTime()+tolerance < set.beatMap.HitObjects[object.GetNumber()].GetStartTime() {
 						return Shake
 					}
 				} else {
@@ -715,16 +1344,39 @@ func (set *OsuRuleSet) CanBeHit(time int64, object HitObject, player *difficulty
 	}
 
 	if math.Abs(float64(time-int64(set.beatMap.HitObjects[object.GetNumber()].GetStartTime()))) >= hitRange {
+		early := set.beatMap.HitObjects[object.GetNumber()].GetStartTime() - float64(time)
+
+		// Click is later judged as a miss because it's outside 50's window
+		if settings.Gameplay.EarlyClicksMiss && early > 0 && early < hitRange+settings.Gameplay.EarlyMissWindow {
+			return Click
+		}
+
 		return Shake
 	}
 
 	return Click
 }
 
+// isOneLifeFail checks if the result should end the run in 1-life mode
+func isOneLifeFail(result HitResult, comboResult ComboResult) bool {
+	oneLife := settings.Gameplay.OneLife
+
+	switch {
+	case result&BaseHitsM == Miss:
+		return true
+	case oneLife.FailOnSliderBreak && comboResult == Reset:
+		return true
+	case oneLife.FailOn50 && result&BaseHitsM == Hit50:
+		return true
+	}
+
+	return false
+}
+
 func (set *OsuRuleSet) failInternal(player *difficultyPlayer) {
 	subSet := set.cursors[player.cursor]
 
-	if player.cursor.IsReplay && settings.Gameplay.IgnoreFailsInReplays {
+	if (player.cursor.IsReplay && settings.Gameplay.IgnoreFailsInReplays) || set.practice {
 		return
 	}
 
@@ -751,13 +1403,52 @@ func (set *OsuRuleSet) failInternal(player *difficultyPlayer) {
 func (set *OsuRuleSet) PlayerStopped(cursor *graphics.Cursor, time int64) {
 	subSet := set.cursors[cursor]
 
-	// Let's believe in hp system. 1ms just in case for slider calculation inconsistencies
-	if time < int64(set.beatMap.HitObjects[len(set.beatMap.HitObjects)-1].GetEndTime())-1 /*+subSet.player.diff.Hit50+20*/ {
+	time += subSet.timeOffset
+
+	endTime := set.beatMap.HitObjects[len(set.beatMap.HitObjects)-1].GetEndTime()
+
+	// Replays can't go past the end of the song, objects after it can't be played anyway
+	if set.audioLength > 0 {
+		endTime = math.Min(endTime, set.audioLength)
+	}
+
+	// Let's believe in hp system. Default 1ms just in case for slider calculation inconsistencies
+	if time < int64(endTime)-settings.Gameplay.ReplayEndLeniency /*+subSet.player.diff.Hit50+20*/ {
+		// Objects not judged yet will time out as misses, so score stays consistent for export
+		if window := settings.Gameplay.TruncatedReplayWindow; window > 0 && time >= int64(endTime)-window {
+			subSet.truncated = true
+
+			log.Printf("%s's replay ends %dms before the last object, finishing it without a fail", cursor.Name, int64(endTime)-time)
+
+			return
+		}
+
 		subSet.forceFail = true
 		subSet.hp.Increase(-10000, true)
 	}
 }
 
+// IsTruncated reports whether cursor's replay ended early but within Gameplay.TruncatedReplayWindow, so it was finished without a fail
+func (set *OsuRuleSet) IsTruncated(cursor *graphics.Cursor) bool {
+	return set.cursors[cursor].truncated
+}
+
+// SetAudioLength sets the length of the song in ms, 0 if unknown
+func (set *OsuRuleSet) SetAudioLength(length float64) {
+	set.audioLength = length
+}
+
+// SetTimeOffset sets the offset in ms added to cursor's time before judging its input.
+// Useful for aligning replays that were played with different local offsets.
+// For live players it replaces Gameplay.InputLatency compensation.
+func (set *OsuRuleSet) SetTimeOffset(cursor *graphics.Cursor, offset int64) {
+	set.cursors[cursor].timeOffset = offset
+}
+
+func (set *OsuRuleSet) GetTimeOffset(cursor *graphics.Cursor) int64 {
+	return set.cursors[cursor].timeOffset
+}
+
 func (set *OsuRuleSet) SetListener(listener hitListener) {
 	set.hitListener = listener
 }
@@ -770,24 +1461,478 @@ func (set *OsuRuleSet) SetFailListener(listener failListener) {
 	set.failListener = listener
 }
 
+func (set *OsuRuleSet) SetDesyncListener(listener desyncListener) {
+	set.desyncListener = listener
+}
+
+// SetStepListener sets the function called on each RunToEnd step before cursors are judged, it should move cursors and set their keys
+func (set *OsuRuleSet) SetStepListener(listener stepListener) {
+	set.stepListener = listener
+}
+
 func (set *OsuRuleSet) GetScore(cursor *graphics.Cursor) Score {
 	return *(set.cursors[cursor].score)
 }
 
+// GetMaxScore returns the score the cursor would get with an SS play, spinner bonus excluded
+func (set *OsuRuleSet) GetMaxScore(cursor *graphics.Cursor) int64 {
+	return set.cursors[cursor].scoreProcessor.GetMaxScore()
+}
+
+// GetHitMap returns whether each object was hit (not missed) by the cursor, indexed the same as beatMap.HitObjects.
+// Objects that weren't judged yet are reported as not hit.
+func (set *OsuRuleSet) GetHitMap(cursor *graphics.Cursor) []bool {
+	hitMap := make([]bool, len(set.cursors[cursor].hitMap))
+	copy(hitMap, set.cursors[cursor].hitMap)
+
+	return hitMap
+}
+
+// GetStarsPP220930 returns the full map star rating calculated by danser's pp220930 implementation
+func (set *OsuRuleSet) GetStarsPP220930(cursor *graphics.Cursor) float64 {
+	return set.cursors[cursor].starsPP220930
+}
+
+// GetStarsRosuPP returns the full map star rating calculated by rosu-pp through FFI
+func (set *OsuRuleSet) GetStarsRosuPP(cursor *graphics.Cursor) float64 {
+	return set.cursors[cursor].starsRosuPP
+}
+
+// GetConvertSSPP returns rosu-pp value of a perfect play in the mode chosen by Gameplay.ConvertMode.
+// It's never awarded to the score, Score.PP stays 0 in converted modes. Returns 0 for osu! mode.
+func (set *OsuRuleSet) GetConvertSSPP(cursor *graphics.Cursor) float64 {
+	return set.cursors[cursor].convertSSPP
+}
+
+// GetStars returns the star rating chosen by settings.Gameplay.StarRatingSource
+func (set *OsuRuleSet) GetStars(cursor *graphics.Cursor) float64 {
+	if settings.Gameplay.StarRatingSource == "rosuPP" || set.cursors[cursor].performance.Mode != 0 {
+		return set.GetStarsRosuPP(cursor)
+	}
+
+	return set.GetStarsPP220930(cursor)
+}
+
+// GetTimingBias returns the mean hit error of circles and slider heads in ms, negative values mean hitting early
+func (set *OsuRuleSet) GetTimingBias(cursor *graphics.Cursor) float64 {
+	subSet := set.cursors[cursor]
+
+	if subSet.hitErrorCount == 0 {
+		return 0
+	}
+
+	return subSet.hitErrorSum / float64(subSet.hitErrorCount)
+}
+
+// GetUnstableRate returns 10 times the standard deviation of hit errors of circles and slider heads in real time, misses are excluded
+func (set *OsuRuleSet) GetUnstableRate(cursor *graphics.Cursor) float64 {
+	return set.cursors[cursor].score.UnstableRate
+}
+
+func (subSet *subSet) unstableRate() float64 {
+	if subSet.hitErrorCount == 0 {
+		return 0
+	}
+
+	mean := subSet.hitErrorSum / float64(subSet.hitErrorCount)
+	variance := math.Max(subSet.hitErrorSqSum/float64(subSet.hitErrorCount)-mean*mean, 0)
+
+	return 10 * math.Sqrt(variance) / subSet.player.diff.Speed
+}
+
+// GetChokeInfo reports whether the first miss happened after the first 90% of objects were played without misses
+func (set *OsuRuleSet) GetChokeInfo(cursor *graphics.Cursor) ChokeInfo {
+	subSet := set.cursors[cursor]
+
+	if subSet.firstMiss < 0 || float64(subSet.firstMiss) < chokeThreshold*float64(len(set.beatMap.HitObjects)) {
+		return ChokeInfo{Object: subSet.firstMiss}
+	}
+
+	return ChokeInfo{
+		Choked:    true,
+		Object:    subSet.firstMiss,
+		ComboLost: subSet.firstMissCombo,
+	}
+}
+
+// GetTopPPObject returns the index of the object which gave the most pp when judged and the amount, -1 if nothing was judged yet
+func (set *OsuRuleSet) GetTopPPObject(cursor *graphics.Cursor) (int, float64) {
+	subSet := set.cursors[cursor]
+
+	if subSet.numObjects == 0 {
+		return -1, 0
+	}
+
+	return maxDelta(subSet.ppDeltas)
+}
+
+func maxDelta(deltas []float64) (index int, value float64) {
+	index = -1
+
+	for i, d := range deltas {
+		if index == -1 || d > value {
+			index, value = i, d
+		}
+	}
+
+	return
+}
+
+// GetScoreVersion returns 2 if cursor is scored with ScoreV2, 1 otherwise
+func (set *OsuRuleSet) GetScoreVersion(cursor *graphics.Cursor) int {
+	return set.cursors[cursor].scoreProcessor.Version()
+}
+
+// GetActiveMods returns mods of given cursor as separate values, see difficulty.Modifier.Split
+func (set *OsuRuleSet) GetActiveMods(cursor *graphics.Cursor) []difficulty.Modifier {
+	return set.cursors[cursor].player.diff.Mods.Split()
+}
+
+// GetCalibrationOffset returns the offset applied by auto-calibration, 0 if it's disabled or hasn't happened yet
+func (set *OsuRuleSet) GetCalibrationOffset(cursor *graphics.Cursor) int64 {
+	return set.cursors[cursor].calibrationOffset
+}
+
+// GetRelaxStats returns auto-tap statistics, they are collected only if cursor has Relax enabled
+func (set *OsuRuleSet) GetRelaxStats(cursor *graphics.Cursor) RelaxStats {
+	return set.cursors[cursor].relaxStats
+}
+
+// GetPlayfieldTransform returns transform that active mods apply to osu!pixel positions, HardRock flips the playfield vertically
+func (set *OsuRuleSet) GetPlayfieldTransform(cursor *graphics.Cursor) mgl32.Mat4 {
+	if set.cursors[cursor].player.diff.CheckModActive(difficulty.HardRock) {
+		return mgl32.Translate3D(0, 384, 0).Mul4(mgl32.Scale3D(1, -1, 1))
+	}
+
+	return mgl32.Ident4()
+}
+
+// GetPPEfficiency returns current pp divided by SS star rating of the map with cursor's mods, 0 if star rating is 0
+func (set *OsuRuleSet) GetPPEfficiency(cursor *graphics.Cursor) float64 {
+	subSet := set.cursors[cursor]
+
+	attribs := set.oppDiffs[difficulty.GetDiffMaskedMods(subSet.player.diff.Mods)]
+	if len(attribs) == 0 || attribs[len(attribs)-1].Total <= 0 {
+		return 0
+	}
+
+	return subSet.score.PP / attribs[len(attribs)-1].Total
+}
+
+// GetRank returns cursor's live 1-based rank by score among all cursors, ties are broken by name
+func (set *OsuRuleSet) GetRank(cursor *graphics.Cursor) int {
+	rank := 1
+
+	for c := range set.cursors {
+		if c != cursor && set.ranksAbove(c, cursor) {
+			rank++
+		}
+	}
+
+	return rank
+}
+
+// ranksAbove reports whether cursor a is ranked above cursor b
+func (set *OsuRuleSet) ranksAbove(a, b *graphics.Cursor) bool {
+	scoreA, scoreB := set.cursors[a].scoreProcessor.GetScore(), set.cursors[b].scoreProcessor.GetScore()
+
+	if scoreA != scoreB {
+		return scoreA > scoreB
+	}
+
+	return a.Name < b.Name
+}
+
+// GetPPPerMiss returns how much pp one more miss would cost at the current state, assuming it replaces a 300.
+// The value is cached until the next judgement, so it's safe to call every frame.
+func (set *OsuRuleSet) GetPPPerMiss(cursor *graphics.Cursor) float64 {
+	subSet := set.cursors[cursor]
+	score := subSet.score
+
+	if score.Count300 == 0 || set.partial || subSet.performance.Mode != 0 {
+		return 0
+	}
+
+	if subSet.ppPerMissObjects != subSet.numObjects {
+		current := set.whatIfPP(subSet, score.Count300, score.Count100, score.Count50, score.CountMiss)
+		worse := set.whatIfPP(subSet, score.Count300-1, score.Count100, score.Count50, score.CountMiss+1)
+
+		subSet.ppPerMiss = math.Max(0, current-worse)
+		subSet.ppPerMissObjects = subSet.numObjects
+	}
+
+	return subSet.ppPerMiss
+}
+
+// whatIfPP calculates blended pp of judged objects with given hit counts and current max combo
+func (set *OsuRuleSet) whatIfPP(subSet *subSet, n300, n100, n50, nMiss uint) float64 {
+	total := n300 + n100 + n50 + nMiss
+	accuracy := 100 * float64(n300*300+n100*100+n50*50) / float64(total*300)
+
+	rosu := subSet.performance.Calculate(ScoreParams{
+		Mode:          subSet.performance.Mode,
+		Mods:          uint(subSet.player.diff.Mods),
+		MaxCombo:      subSet.score.Combo,
+		Accuracy:      accuracy,
+		MissCount:     nMiss,
+		PassedObjects: total,
+	})
+
+	attribs := set.oppDiffs[difficulty.GetDiffMaskedMods(subSet.player.diff.Mods)][total-1]
+
+	ppv2 := &pp220930.PPv2{}
+	ppv2.PPv2x(attribs, int(subSet.score.Combo), int(n300), int(n100), int(n50), int(nMiss), subSet.player.diff)
+
+	blend := settings.Gameplay.PPBlend
+
+	return blend*rosu.PP + (1-blend)*ppv2.Results.Total
+}
+
+type cachedPP struct {
+	pp      float64
+	objects uint
+}
+
+// GetPPWithExtraMod returns rosu-pp (FFI) pp of judged objects at current accuracy and combo as if mod was added to cursor's mods.
+// Results are cached per mod combination until the next judgement.
+func (set *OsuRuleSet) GetPPWithExtraMod(cursor *graphics.Cursor, mod difficulty.Modifier) float64 {
+	subSet := set.cursors[cursor]
+
+	if subSet.numObjects == 0 || set.partial || subSet.performance.Mode != 0 {
+		return 0
+	}
+
+	mods := subSet.player.diff.Mods | mod
+
+	if cached, ok := subSet.extraModPP[mods]; ok && cached.objects == subSet.numObjects {
+		return cached.pp
+	}
+
+	params := subSet.ppParams
+	params.Mods = uint(mods)
+
+	pp := subSet.performance.Calculate(params).PP
+
+	subSet.extraModPP[mods] = cachedPP{pp: pp, objects: subSet.numObjects}
+
+	return pp
+}
+
+// GetAimTapRatio returns SS aim pp divided by SS speed pp for given mods, above 1 means map is aim-heavy. Returns 0 if mods weren't used by any cursor
+func (set *OsuRuleSet) GetAimTapRatio(mods difficulty.Modifier) float64 {
+	return set.ssPP[difficulty.GetDiffMaskedMods(mods)].AimTapRatio()
+}
+
+// GetAccuracies returns stable accuracy and lazer-like accuracy that also weights slider heads, ticks and ends
+func (set *OsuRuleSet) GetAccuracies(cursor *graphics.Cursor) (stable, lazer float64) {
+	subSet := set.cursors[cursor]
+
+	return subSet.score.Accuracy, subSet.lazerAccuracy()
+}
+
+// GetDisplayAccuracy returns accuracy shown in HUD and results. ScoreV2 shows lazer-like accuracy, like lazer does.
+// Lazer weighting is deliberately limited to display: Score.Accuracy used for pp, grades and submissions always
+// counts object judgements only, so they stay comparable with stable scores.
+func (set *OsuRuleSet) GetDisplayAccuracy(cursor *graphics.Cursor) float64 {
+	subSet := set.cursors[cursor]
+
+	if subSet.scoreProcessor.Version() == 2 {
+		return subSet.lazerAccuracy()
+	}
+
+	return subSet.score.Accuracy
+}
+
+// GetSliderBreaks returns all slider breaks of the cursor in the order they happened
+func (set *OsuRuleSet) GetSliderBreaks(cursor *graphics.Cursor) []SliderBreak {
+	return set.cursors[cursor].sliderBreaks
+}
+
+// GetSpinnerProgress returns progress of the spinner that is currently spun by the cursor, Active is false if there's none
+func (set *OsuRuleSet) GetSpinnerProgress(cursor *graphics.Cursor) SpinnerProgress {
+	player := set.cursors[cursor].player
+
+	for _, o := range set.processed {
+		if s, ok := o.(*Spinner); ok {
+			if progress := s.getProgress(player); progress.Active {
+				return progress
+			}
+		}
+	}
+
+	return SpinnerProgress{}
+}
+
+// GetSpinnerRequirement returns how many rotations cursor needs to clear the spinner with given number, -1 if that object is not a spinner
+func (set *OsuRuleSet) GetSpinnerRequirement(cursor *graphics.Cursor, number int64) int64 {
+	if number < 0 || number >= int64(len(set.beatMap.HitObjects)) {
+		return -1
+	}
+
+	spinner, ok := set.beatMap.HitObjects[number].(*objects.Spinner)
+	if !ok {
+		return -1
+	}
+
+	player := set.cursors[cursor].player
+
+	requirement := baseSpinnerRequirement(int64(spinner.GetEndTime())-int64(spinner.GetStartTime()), player.diff)
+
+	if player.cursor.OldSpinnerScoring {
+		requirement++
+	}
+
+	return requirement
+}
+
+// GetBPMAt returns the BPM of the timing point active at given time, adjusted by speed changing mods
+func (set *OsuRuleSet) GetBPMAt(time float64) float64 {
+	return 60000 / set.beatMap.Timings.GetPointAt(time).GetBaseBeatLength() * set.beatMap.Diff.Speed
+}
+
+// GetTimingAt returns timing point information active at given time
+func (set *OsuRuleSet) GetTimingAt(time int64) TimingInfo {
+	timings := set.beatMap.Timings
+
+	point, original := timings.GetDefault(), timings.GetDefault()
+
+	if timings.HasPoints() {
+		point = timings.GetPointAt(float64(time))
+		original = timings.GetOriginalPointAt(float64(time))
+	}
+
+	return TimingInfo{
+		BPM:          60000 / point.GetBaseBeatLength() * set.beatMap.Diff.Speed,
+		BeatLength:   point.GetBaseBeatLength(),
+		BeatStart:    original.Time,
+		Meter:        point.Signature,
+		SVMultiplier: 1 / point.GetRatio(),
+		Kiai:         point.Kiai,
+	}
+}
+
+// GetMainBPM returns the BPM that lasts the longest in the map, adjusted by speed changing mods
+func (set *OsuRuleSet) GetMainBPM() float64 {
+	return 60000 / set.beatMap.Timings.GetMainBeatLength(set.beatMap.HitObjects[len(set.beatMap.HitObjects)-1].GetEndTime()) * set.beatMap.Diff.Speed
+}
+
+// GetMapInfo returns beatmap's metadata, star rating and SS pp with beatmap's mods. Stars and pp are 0 if no cursor uses those mods
+func (set *OsuRuleSet) GetMapInfo() MapInfo {
+	bMap := set.beatMap
+	speed := bMap.Diff.Speed
+
+	info := MapInfo{
+		Title:   bMap.Name,
+		Artist:  bMap.Artist,
+		Creator: bMap.Creator,
+		Version: bMap.Difficulty,
+		MinBPM:  bMap.MinBPM * speed,
+		MaxBPM:  bMap.MaxBPM * speed,
+		MainBPM: set.GetMainBPM(),
+		Length:  bMap.HitObjects[len(bMap.HitObjects)-1].GetEndTime() / speed,
+	}
+
+	if math.IsInf(bMap.MinBPM, 0) { // no timing points parsed
+		info.MinBPM, info.MaxBPM = info.MainBPM, info.MainBPM
+	}
+
+	maskedMods := difficulty.GetDiffMaskedMods(bMap.Diff.Mods)
+
+	if attribs := set.oppDiffs[maskedMods]; len(attribs) > 0 {
+		info.Stars = attribs[len(attribs)-1].Total
+	}
+
+	info.MaxPP = set.ssPP[maskedMods].Total
+
+	return info
+}
+
+// GetScoreTimeline returns score, pp and combo recorded after each judgement
+func (set *OsuRuleSet) GetScoreTimeline(cursor *graphics.Cursor) []TimelinePoint {
+	timeline := make([]TimelinePoint, len(set.cursors[cursor].timeline))
+	copy(timeline, set.cursors[cursor].timeline)
+
+	return timeline
+}
+
+// IsEnded returns true once all objects have been judged
+func (set *OsuRuleSet) IsEnded() bool {
+	return set.ended
+}
+
 func (set *OsuRuleSet) GetHP(cursor *graphics.Cursor) float64 {
 	subSet := set.cursors[cursor]
 	return subSet.hp.Health / MaxHp
 }
 
+// GetHPSmooth returns cursor's health animated towards GetHP, meant for drawing. Use GetHP for game logic
+func (set *OsuRuleSet) GetHPSmooth(cursor *graphics.Cursor) float64 {
+	return set.cursors[cursor].smoothHP
+}
+
+// updateSmoothHP moves smoothHP exponentially towards real health, independently of the update rate
+func (subSet *subSet) updateSmoothHP(time int64) {
+	target := subSet.hp.Health / MaxHp
+
+	if subSet.lastHPUpdate == math.MinInt64 || time < subSet.lastHPUpdate {
+		subSet.smoothHP = target
+	} else {
+		subSet.smoothHP += (target - subSet.smoothHP) * (1 - math.Exp(-float64(time-subSet.lastHPUpdate)/hpSmoothingTime))
+	}
+
+	subSet.lastHPUpdate = time
+}
+
 func (set *OsuRuleSet) GetPlayer(cursor *graphics.Cursor) *difficultyPlayer {
 	subSet := set.cursors[cursor]
 	return subSet.player
 }
 
+// Dispose closes hit event subscriptions, the ruleset can't be used afterwards
+func (set *OsuRuleSet) Dispose() {
+	set.closeSubscribers()
+}
+
+// IsObjectActive tells if object with given number is currently being processed, i.e. it's visible and not yet finished by all cursors
+func (set *OsuRuleSet) IsObjectActive(number int64) bool {
+	for _, o := range set.processed {
+		if o.GetNumber() == number {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (set *OsuRuleSet) GetProcessed() []HitObject {
 	return set.processed
 }
 
+// GetProcessedStates returns the state of all currently processed objects, ordered like GetProcessed
+func (set *OsuRuleSet) GetProcessedStates() []ObjectState {
+	states := make([]ObjectState, 0, len(set.processed))
+
+	for _, o := range set.processed {
+		bObj := set.beatMap.HitObjects[o.GetNumber()]
+
+		state := ObjectState{
+			Number:    o.GetNumber(),
+			FadeTime:  o.GetFadeTime(),
+			StartTime: bObj.GetStartTime(),
+			EndTime:   bObj.GetEndTime(),
+			Hit:       make(map[*graphics.Cursor]bool, len(set.cursors)),
+		}
+
+		for cursor, subSet := range set.cursors {
+			state.Hit[cursor] = o.IsHit(subSet.player)
+		}
+
+		states = append(states, state)
+	}
+
+	return states
+}
+
 func (set *OsuRuleSet) GetBeatMap() *beatmap.BeatMap {
 	return set.beatMap
 }
@@ -2,6 +2,7 @@ package osu
 
 import (
 	"github.com/wieku/danser-go/app/beatmap"
+	"github.com/wieku/danser-go/app/beatmap/objects"
 	"github.com/wieku/danser-go/framework/math/mutils"
 	"math"
 )
@@ -11,6 +12,7 @@ type scoreV1Processor struct {
 	combo           int64
 	modMultiplier   float64
 	scoreMultiplier float64
+	maxScore        int64
 }
 
 func newScoreV1Processor() *scoreV1Processor {
@@ -29,6 +31,43 @@ func (s *scoreV1Processor) Init(beatMap *beatmap.BeatMap, player *difficultyPlay
 
 	// HACK: we need to cast to float32 then to float64 to lose some precision but calculate them again as float64s to have matching results with osu!stable
 	s.scoreMultiplier = math.RoundToEven((float64(float32(beatMap.Diff.GetHP())) + float64(float32(beatMap.Diff.GetOD())) + float64(float32(beatMap.Diff.GetCS())) + float64(mutils.ClampF(float32(len(beatMap.HitObjects))/drainTime*8, 0, 16))) / 38 * 5)
+
+	s.maxScore = s.calculateMaxScore(beatMap)
+}
+
+// calculateMaxScore simulates an SS play on a fresh processor. Spinners are counted without spin bonus as it depends on the player.
+func (s *scoreV1Processor) calculateMaxScore(beatMap *beatmap.BeatMap) int64 {
+	sim := &scoreV1Processor{
+		modMultiplier:   s.modMultiplier,
+		scoreMultiplier: s.scoreMultiplier,
+	}
+
+	for i, o := range beatMap.HitObjects {
+		if !passesPracticeFilter(beatMap, i) {
+			continue
+		}
+
+		if slider, ok := o.(*objects.Slider); ok {
+			sim.AddResult(SliderStart, Increase)
+
+			for j, point := range slider.ScorePoints {
+				switch {
+				case j == len(slider.ScorePoints)-1:
+					sim.AddResult(SliderEnd, Increase)
+				case point.IsReverse:
+					sim.AddResult(SliderRepeat, Increase)
+				default:
+					sim.AddResult(SliderPoint, Increase)
+				}
+			}
+
+			sim.AddResult(Hit300, Hold)
+		} else {
+			sim.AddResult(Hit300, Increase)
+		}
+	}
+
+	return sim.score
 }
 
 func (s *scoreV1Processor) AddResult(result HitResult, comboResult ComboResult) {
@@ -62,3 +101,23 @@ func (s *scoreV1Processor) GetScore() int64 {
 func (s *scoreV1Processor) GetCombo() int64 {
 	return s.combo
 }
+
+func (s *scoreV1Processor) GetMaxScore() int64 {
+	return s.maxScore
+}
+
+func (s *scoreV1Processor) Version() int {
+	return 1
+}
+
+func (s *scoreV1Processor) SaveState() processorState {
+	return processorState{
+		Score: s.score,
+		Combo: s.combo,
+	}
+}
+
+func (s *scoreV1Processor) LoadState(state processorState) {
+	s.score = state.Score
+	s.combo = state.Combo
+}
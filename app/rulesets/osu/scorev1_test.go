@@ -0,0 +1,27 @@
+package osu
+
+import (
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+)
+
+func TestMaxScoreV1(t *testing.T) {
+	beatMap := loadTestMap(t, "circles.osu")
+
+	ruleset, cursor := newTestRuleset(t, beatMap, difficulty.None, 0)
+
+	// circles.osu: HP5 + OD8 + CS4 + density clamped to 16 gives score multiplier round(33/38*5) = 4,
+	// so the nth circle gives 300 + 300*max(n-1, 0)*4/25. 40 circles: 40*300 + 48*(0+0+1+...+38) = 47568
+	const expected = 47568
+
+	if maxScore := ruleset.GetMaxScore(cursor); maxScore != expected {
+		t.Fatalf("expected max score %d, got %d", expected, maxScore)
+	}
+
+	ruleset.RunToEnd(1)
+
+	if score := ruleset.GetScore(cursor).Score; score != expected {
+		t.Errorf("SS play scored %d, expected max score %d", score, expected)
+	}
+}
@@ -34,7 +34,11 @@ func (s *scoreV2Processor) Init(beatMap *beatmap.BeatMap, player *difficultyPlay
 	s.maxHits = 0
 	s.hitMap = make(map[HitResult]int64)
 
-	for _, o := range beatMap.HitObjects {
+	for i, o := range beatMap.HitObjects {
+		if !passesPracticeFilter(beatMap, i) {
+			continue
+		}
+
 		if o.GetType() == objects.CIRCLE || o.GetType() == objects.SPINNER {
 			s.AddResult(Hit300, Increase)
 		} else if slider, ok := o.(*objects.Slider); ok {
@@ -116,6 +120,48 @@ func (s *scoreV2Processor) GetCombo() int64 {
 	return s.combo
 }
 
+func (s *scoreV2Processor) GetMaxScore() int64 {
+	return int64(math.Round(1000000 * s.modMultiplier))
+}
+
+func (s *scoreV2Processor) Version() int {
+	return 2
+}
+
+func (s *scoreV2Processor) SaveState() processorState {
+	hitMap := make(map[HitResult]int64, len(s.hitMap))
+	for k, v := range s.hitMap {
+		hitMap[k] = v
+	}
+
+	return processorState{
+		Score:     s.score,
+		Combo:     s.combo,
+		ComboPart: s.comboPart,
+		Bonus:     s.bonus,
+		Hits:      s.hits,
+		HitMap:    hitMap,
+	}
+}
+
+func (s *scoreV2Processor) LoadState(state processorState) {
+	s.score = state.Score
+	s.combo = state.Combo
+	s.comboPart = state.ComboPart
+	s.bonus = state.Bonus
+	s.hits = state.Hits
+
+	s.hitMap = make(map[HitResult]int64, len(state.HitMap))
+	for k, v := range state.HitMap {
+		s.hitMap[k] = v
+	}
+}
+
+// FloorAccuracy floors accuracy in percent to 2 decimal places, the way lazer displays it
+func FloorAccuracy(accuracy float64) float64 {
+	return math.Floor(accuracy/100*10000) / 100
+}
+
 func scoreValueV2(result HitResult) int64 {
 	scoreVal := result.ScoreValue()
 	if result&SpinnerBonus > 0 {
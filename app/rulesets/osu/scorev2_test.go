@@ -0,0 +1,20 @@
+package osu
+
+import (
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+)
+
+func TestGetScoreVersion(t *testing.T) {
+	ruleset, cursors := newScriptedRuleset(t, loadTestMap(t, "circles.osu"),
+		testPlayer{name: "v1", mods: difficulty.Hidden, script: clickAll(0)},
+		testPlayer{name: "v2", mods: difficulty.Hidden | difficulty.ScoreV2, script: clickAll(0)},
+	)
+
+	for i, expected := range []int{1, 2} {
+		if version := ruleset.GetScoreVersion(cursors[i]); version != expected {
+			t.Errorf("%s: expected ScoreV%d, got ScoreV%d", cursors[i].Name, expected, version)
+		}
+	}
+}
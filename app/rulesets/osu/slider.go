@@ -3,6 +3,7 @@ package osu
 import (
 	"github.com/wieku/danser-go/app/beatmap/difficulty"
 	"github.com/wieku/danser-go/app/beatmap/objects"
+	"github.com/wieku/danser-go/app/settings"
 	"github.com/wieku/danser-go/framework/math/mutils"
 	"github.com/wieku/danser-go/framework/math/vector"
 	"math"
@@ -224,7 +225,8 @@ func (slider *Slider) UpdateFor(player *difficultyPlayer, time int64, processSli
 
 		radiusNeeded := player.diff.CircleRadius
 		if state.sliding {
-			radiusNeeded *= 2.4
+			// Strict tracking shrinks only the judgement radius, follow circle keeps its visual size
+			radiusNeeded *= math.Max(1, 2.4*settings.Gameplay.SliderTrackingRadius)
 		}
 
 		allowable := mouseDownAcceptable && player.cursor.RawPosition.Dst(sliderPosition) <= float32(radiusNeeded)
@@ -378,3 +380,21 @@ func (slider *Slider) GetStartResult(pl *difficultyPlayer) HitResult {
 func (slider *Slider) GetFadeTime() int64 {
 	return int64(slider.hitSlider.GetStartTime() - slider.fadeStartRelative)
 }
+
+// getComponentWeight returns lazer-like accuracy weight of slider's part that produced the result, ends are worth more than heads and ticks
+func (slider *Slider) getComponentWeight(player *difficultyPlayer, result HitResult) float64 {
+	if result == SliderEnd {
+		return 150
+	}
+
+	if result == SliderMiss {
+		state := slider.state[player]
+
+		// Head misses are sent before the head is marked as judged, tick misses are counted before they are sent
+		if state.isStartHit && state.scored+state.missed == len(state.points) {
+			return 150
+		}
+	}
+
+	return 30
+}
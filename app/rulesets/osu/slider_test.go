@@ -0,0 +1,67 @@
+package osu
+
+import (
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+	"github.com/wieku/danser-go/app/graphics"
+	"github.com/wieku/danser-go/app/settings"
+	"github.com/wieku/danser-go/framework/math/vector"
+)
+
+func TestStrictSliderTracking(t *testing.T) {
+	trackingRadius := settings.Gameplay.SliderTrackingRadius
+
+	t.Cleanup(func() { settings.Gameplay.SliderTrackingRadius = trackingRadius })
+
+	// sliders.osu has CS4 (circle radius ~62), so the cursor 100 o!px away from the ball keeps tracking
+	// with stable's 2.4x follow radius (~149) but not with half of it (~74)
+	tests := []struct {
+		name           string
+		trackingRadius float64
+		count300       uint
+	}{
+		{"stable", 1, 10},
+		{"strict", 0.5, 0},
+	}
+
+	for _, tt := range tests {
+		settings.Gameplay.SliderTrackingRadius = tt.trackingRadius
+
+		beatMap := loadTestMap(t, "sliders.osu")
+
+		cursor := graphics.NewHeadlessCursor()
+		cursor.IsReplay = true
+
+		ruleset := NewOsuRuleset(beatMap, []*graphics.Cursor{cursor}, []difficulty.Modifier{difficulty.None})
+
+		ruleset.SetStepListener(func(time int64) {
+			cursor.LeftButton = false
+
+			for _, obj := range beatMap.HitObjects {
+				start, end := int64(obj.GetStartTime()), int64(obj.GetEndTime())
+
+				if time < start || time > end {
+					continue
+				}
+
+				// hit the head on the ball, then follow it from below
+				pos := obj.GetStackedPositionAt(float64(time))
+				if time > start+testClickLength {
+					pos = pos.Add(vector.NewVec2f(0, 100))
+				}
+
+				cursor.SetPos(pos)
+				cursor.LeftButton = true
+			}
+		})
+
+		ruleset.RunToEnd(1)
+
+		score := ruleset.GetScore(cursor)
+
+		if score.Count300 != tt.count300 || score.CountMiss != 0 {
+			t.Errorf("%s: expected %d 300s and no misses, got 300: %d, 100: %d, 50: %d, miss: %d", tt.name, tt.count300, score.Count300, score.Count100, score.Count50, score.CountMiss)
+		}
+	}
+}
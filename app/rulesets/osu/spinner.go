@@ -23,6 +23,17 @@ type spinnerstate struct {
 	zeroCount            int64
 	rpm                  float64
 	updatedBefore        bool
+	lastTime             int64
+}
+
+// SpinnerProgress describes the state of currently spun spinner
+type SpinnerProgress struct {
+	Active    bool
+	Rotations int64 // scoring rotations so far
+	Required  int64 // rotations needed to clear the spinner
+	Cleared   bool
+	Bonus     int64 // number of awarded bonus (1000 points) rotations
+	RPM       float64
 }
 
 type Spinner struct {
@@ -53,7 +64,7 @@ func (spinner *Spinner) Init(ruleSet *OsuRuleSet, object objects.IHitObject, pla
 	for _, player := range spinner.players {
 		spinner.state[player] = new(spinnerstate)
 		spinner.fadeStartRelative = math.Min(spinner.fadeStartRelative, player.diff.Preempt)
-		spinner.state[player].requirement = int64(float64(spinnerTime) / 1000 * player.diff.SpinnerRatio)
+		spinner.state[player].requirement = baseSpinnerRequirement(spinnerTime, player.diff)
 		spinner.state[player].frameVariance = FrameTime
 	}
 
@@ -70,6 +81,7 @@ func (spinner *Spinner) UpdateFor(player *difficultyPlayer, time int64, _ bool)
 	spinnerPosition := spinner.hitSpinner.GetStackedStartPosition()
 
 	state := spinner.state[player]
+	state.lastTime = time
 
 	timeDiff := float64(time - player.cursor.LastFrameTime)
 	if player.cursor.LastFrameTime == 0 {
@@ -249,10 +261,37 @@ func (spinner *Spinner) IsHit(pl *difficultyPlayer) bool {
 	return spinner.state[pl].finished
 }
 
+func (spinner *Spinner) getProgress(player *difficultyPlayer) SpinnerProgress {
+	state, ok := spinner.state[player]
+	if !ok {
+		return SpinnerProgress{}
+	}
+
+	progress := SpinnerProgress{
+		Active:    !state.finished && state.lastTime >= int64(spinner.hitSpinner.GetStartTime()),
+		Rotations: state.scoringRotationCount,
+		Required:  spinner.getRequirementClear(player),
+		RPM:       state.rpm,
+	}
+
+	progress.Cleared = progress.Rotations >= progress.Required
+
+	if bonusStart := state.requirement + 3; state.scoringRotationCount > bonusStart {
+		progress.Bonus = (state.scoringRotationCount - bonusStart) / 2
+	}
+
+	return progress
+}
+
 func (spinner *Spinner) GetFadeTime() int64 {
 	return int64(spinner.hitSpinner.GetStartTime() - spinner.fadeStartRelative)
 }
 
+// baseSpinnerRequirement returns rotation count derived from spinner's length and OD, requirements below are relative to it
+func baseSpinnerRequirement(spinnerTime int64, diff *difficulty.Difficulty) int64 {
+	return int64(float64(spinnerTime) / 1000 * diff.SpinnerRatio)
+}
+
 // new vs old spinner handling helpers
 func (spinner *Spinner) getRequirementMeh(player *difficultyPlayer) int64 {
 	if player.cursor.OldSpinnerScoring {
@@ -0,0 +1,65 @@
+package osu
+
+import (
+	"math"
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+	"github.com/wieku/danser-go/app/settings"
+)
+
+func TestStarRatingGetters(t *testing.T) {
+	beatMap := loadTestMap(t, "circles.osu")
+
+	ruleset, cursors := newScriptedRuleset(t, beatMap,
+		testPlayer{name: "first", mods: difficulty.Hidden, script: clickAll(0)},
+		testPlayer{name: "second", mods: difficulty.Hidden, script: clickAll(0)},
+	)
+
+	for _, cursor := range cursors {
+		for name, stars := range map[string]float64{"pp220930": ruleset.GetStarsPP220930(cursor), "rosu-pp": ruleset.GetStarsRosuPP(cursor)} {
+			if math.IsNaN(stars) || math.IsInf(stars, 0) || stars <= 0 {
+				t.Errorf("%s: expected finite positive %s star rating, got %f", cursor.Name, name, stars)
+			}
+		}
+	}
+
+	if len(ruleset.rosuSS) != 1 {
+		t.Errorf("expected rosu-pp to be called once for cursors with the same mods, got %d results", len(ruleset.rosuSS))
+	}
+
+	if a, b := ruleset.GetStarsRosuPP(cursors[0]), ruleset.GetStarsRosuPP(cursors[1]); a != b {
+		t.Errorf("expected the same rosu-pp star rating for both cursors, got %f and %f", a, b)
+	}
+}
+
+func TestConvertModePP(t *testing.T) {
+	beatMap := loadTestMap(t, "circles.osu")
+
+	ruleset, cursors := newScriptedRuleset(t, beatMap, testPlayer{name: "osu", mods: difficulty.None, script: clickAll(0)})
+	ruleset.RunToEnd(1)
+
+	if ssPP := ruleset.GetConvertSSPP(cursors[0]); ssPP != 0 {
+		t.Errorf("expected no convert SS pp in osu! mode, got %.2f", ssPP)
+	}
+
+	convertMode := settings.Gameplay.ConvertMode
+	settings.Gameplay.ConvertMode = "taiko"
+
+	t.Cleanup(func() { settings.Gameplay.ConvertMode = convertMode })
+
+	ruleset, cursors = newScriptedRuleset(t, beatMap, testPlayer{name: "taiko", mods: difficulty.None, script: skipObjects(0, 5)})
+	ruleset.RunToEnd(1)
+
+	if ssPP := ruleset.GetConvertSSPP(cursors[0]); ssPP <= 0 {
+		t.Errorf("expected positive convert SS pp, got %.2f", ssPP)
+	}
+
+	if pp := ruleset.GetScore(cursors[0]).PP; pp != 0 {
+		t.Errorf("expected no pp awarded for osu! judgements in converted mode, got %.2f", pp)
+	}
+
+	if stars := ruleset.GetStars(cursors[0]); stars != ruleset.GetStarsRosuPP(cursors[0]) {
+		t.Errorf("expected rosu-pp stars in converted mode, got %.2f", stars)
+	}
+}
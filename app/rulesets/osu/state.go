@@ -0,0 +1,251 @@
+package osu
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// processorState holds the mutable part of a score processor, fields not used by a processor are left empty
+type processorState struct {
+	Score     int64               `json:"score"`
+	Combo     int64               `json:"combo"`
+	ComboPart float64             `json:"comboPart,omitempty"`
+	Bonus     float64             `json:"bonus,omitempty"`
+	Hits      int64               `json:"hits,omitempty"`
+	HitMap    map[HitResult]int64 `json:"hitMap,omitempty"`
+}
+
+type pressState struct {
+	Time    int64   `json:"time"`
+	Buttons Buttons `json:"buttons"`
+}
+
+type deviationState struct {
+	Sum   float64 `json:"sum"`
+	Count int     `json:"count"`
+}
+
+// subSetState holds everything a subSet accumulates while judging, derived values like cached pp are recalculated
+type subSetState struct {
+	Score          Score          `json:"score"`
+	RawScore       int64          `json:"rawScore"`
+	NumObjects     uint           `json:"numObjects"`
+	CurrentKatu    int            `json:"currentKatu"`
+	CurrentBad     int            `json:"currentBad"`
+	Health         float64        `json:"health"`
+	HealthUncapped float64        `json:"healthUncapped"`
+	Processor      processorState `json:"processor"`
+
+	HitMap   []bool    `json:"hitMap"`
+	PPDeltas []float64 `json:"ppDeltas"`
+	LastPP   float64   `json:"lastPP"`
+
+	PPParams   ScoreParams `json:"ppParams"`
+	PPStale    bool        `json:"ppStale"`
+	PPv2AtRosu float64     `json:"ppv2AtRosu"`
+
+	FirstMiss      int64         `json:"firstMiss"`
+	FirstMissCombo uint          `json:"firstMissCombo"`
+	SliderBreaks   []SliderBreak `json:"sliderBreaks"`
+
+	LazerAccScore float64 `json:"lazerAccScore"`
+	LazerAccMax   float64 `json:"lazerAccMax"`
+
+	TimeOffset        int64   `json:"timeOffset"`
+	HitErrorSum       float64 `json:"hitErrorSum"`
+	HitErrorSqSum     float64 `json:"hitErrorSqSum"`
+	HitErrorCount     int     `json:"hitErrorCount"`
+	CalibrationOffset int64   `json:"calibrationOffset"`
+	Calibrated        bool    `json:"calibrated"`
+
+	Timeline   []TimelinePoint  `json:"timeline"`
+	Presses    []pressState     `json:"presses"`
+	Deviations []deviationState `json:"deviations"`
+	RelaxStats RelaxStats       `json:"relaxStats"`
+
+	SmoothHP     float64 `json:"smoothHP"`
+	LastHPUpdate int64   `json:"lastHPUpdate"`
+
+	EarlyProblems [desyncCheckObjects]bool `json:"earlyProblems"`
+	DesyncWarned  bool                     `json:"desyncWarned"`
+
+	Recoveries int  `json:"recoveries"`
+	Failed     bool `json:"failed"`
+	SDPFFail   bool `json:"sdpfFail"`
+	ForceFail  bool `json:"forceFail"`
+	Truncated  bool `json:"truncated"`
+}
+
+func (subSet *subSet) saveState() subSetState {
+	presses := make([]pressState, len(subSet.presses))
+	for i, p := range subSet.presses {
+		presses[i] = pressState{Time: p.time, Buttons: p.buttons}
+	}
+
+	deviations := make([]deviationState, len(subSet.deviations))
+	for i, d := range subSet.deviations {
+		deviations[i] = deviationState{Sum: d.sum, Count: d.count}
+	}
+
+	return subSetState{
+		Score:             *subSet.score,
+		RawScore:          subSet.rawScore,
+		NumObjects:        subSet.numObjects,
+		CurrentKatu:       subSet.currentKatu,
+		CurrentBad:        subSet.currentBad,
+		Health:            subSet.hp.Health,
+		HealthUncapped:    subSet.hp.HealthUncapped,
+		Processor:         subSet.scoreProcessor.SaveState(),
+		HitMap:            subSet.hitMap,
+		PPDeltas:          subSet.ppDeltas,
+		LastPP:            subSet.lastPP,
+		PPParams:          subSet.ppParams,
+		PPStale:           subSet.ppStale,
+		PPv2AtRosu:        subSet.ppv2AtRosu,
+		FirstMiss:         subSet.firstMiss,
+		FirstMissCombo:    subSet.firstMissCombo,
+		SliderBreaks:      subSet.sliderBreaks,
+		LazerAccScore:     subSet.lazerAccScore,
+		LazerAccMax:       subSet.lazerAccMax,
+		TimeOffset:        subSet.timeOffset,
+		HitErrorSum:       subSet.hitErrorSum,
+		HitErrorSqSum:     subSet.hitErrorSqSum,
+		HitErrorCount:     subSet.hitErrorCount,
+		CalibrationOffset: subSet.calibrationOffset,
+		Calibrated:        subSet.calibrated,
+		Timeline:          subSet.timeline,
+		Presses:           presses,
+		Deviations:        deviations,
+		RelaxStats:        subSet.relaxStats,
+		SmoothHP:          subSet.smoothHP,
+		LastHPUpdate:      subSet.lastHPUpdate,
+		EarlyProblems:     subSet.earlyProblems,
+		DesyncWarned:      subSet.desyncWarned,
+		Recoveries:        subSet.recoveries,
+		Failed:            subSet.failed,
+		SDPFFail:          subSet.sdpfFail,
+		ForceFail:         subSet.forceFail,
+		Truncated:         subSet.truncated,
+	}
+}
+
+func (subSet *subSet) loadState(state subSetState) error {
+	if len(state.HitMap) != len(subSet.hitMap) || len(state.Deviations) != len(subSet.deviations) {
+		return fmt.Errorf("state was saved for a different beatmap: %d objects, expected %d", len(state.HitMap), len(subSet.hitMap))
+	}
+
+	*subSet.score = state.Score
+	subSet.rawScore = state.RawScore
+	subSet.numObjects = state.NumObjects
+	subSet.currentKatu = state.CurrentKatu
+	subSet.currentBad = state.CurrentBad
+	subSet.hp.Health = state.Health
+	subSet.hp.HealthUncapped = state.HealthUncapped
+	subSet.scoreProcessor.LoadState(state.Processor)
+
+	subSet.hitMap = state.HitMap
+	subSet.ppDeltas = state.PPDeltas
+	subSet.lastPP = state.LastPP
+
+	subSet.ppParams = state.PPParams
+	subSet.ppStale = state.PPStale
+	subSet.ppv2AtRosu = state.PPv2AtRosu
+
+	subSet.firstMiss = state.FirstMiss
+	subSet.firstMissCombo = state.FirstMissCombo
+	subSet.sliderBreaks = state.SliderBreaks
+
+	subSet.lazerAccScore = state.LazerAccScore
+	subSet.lazerAccMax = state.LazerAccMax
+
+	// input latency compensation and calibration
+	subSet.timeOffset = state.TimeOffset
+
+	subSet.hitErrorSum = state.HitErrorSum
+	subSet.hitErrorSqSum = state.HitErrorSqSum
+	subSet.hitErrorCount = state.HitErrorCount
+	subSet.calibrationOffset = state.CalibrationOffset
+	subSet.calibrated = state.Calibrated
+
+	subSet.timeline = state.Timeline
+
+	subSet.presses = make([]keyPress, len(state.Presses))
+	for i, p := range state.Presses {
+		subSet.presses[i] = keyPress{time: p.Time, buttons: p.Buttons}
+	}
+
+	for i, d := range state.Deviations {
+		subSet.deviations[i] = pathDeviation{sum: d.Sum, count: d.Count}
+	}
+
+	subSet.relaxStats = state.RelaxStats
+
+	subSet.smoothHP = state.SmoothHP
+	subSet.lastHPUpdate = state.LastHPUpdate
+
+	subSet.earlyProblems = state.EarlyProblems
+	subSet.desyncWarned = state.DesyncWarned
+
+	subSet.recoveries = state.Recoveries
+	subSet.failed = state.Failed
+	subSet.sdpfFail = state.SDPFFail
+	subSet.forceFail = state.ForceFail
+	subSet.truncated = state.Truncated
+
+	// cached values depend on the number of judged objects which has just changed
+	subSet.ppPerMissObjects = 0
+	for mods := range subSet.extraModPP {
+		delete(subSet.extraModPP, mods)
+	}
+
+	return nil
+}
+
+// SaveState writes scoring state of all cursors to given path, cursors are identified by their index in NewOsuRuleset.
+// Only scoring is saved: judged objects are not, so after LoadState the caller has to make sure objects
+// judged before saving aren't judged again, e.g. by continuing with RunRange.
+func (set *OsuRuleSet) SaveState(path string) error {
+	states := make([]subSetState, len(set.cursorOrder))
+
+	for i, cursor := range set.cursorOrder {
+		states[i] = set.cursors[cursor].saveState()
+	}
+
+	data, err := json.MarshalIndent(states, "", "\t")
+	if err != nil {
+		return fmt.Errorf("failed to serialize ruleset state: %w", err)
+	}
+
+	if err = os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to save ruleset state: %w", err)
+	}
+
+	return nil
+}
+
+// LoadState restores scoring state saved with SaveState. The ruleset has to be created with the same number of cursors.
+func (set *OsuRuleSet) LoadState(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read ruleset state: %w", err)
+	}
+
+	var states []subSetState
+
+	if err = json.Unmarshal(data, &states); err != nil {
+		return fmt.Errorf("failed to parse ruleset state: %w", err)
+	}
+
+	if len(states) != len(set.cursorOrder) {
+		return fmt.Errorf("state has %d cursors, ruleset has %d", len(states), len(set.cursorOrder))
+	}
+
+	for i, cursor := range set.cursorOrder {
+		if err = set.cursors[cursor].loadState(states[i]); err != nil {
+			return fmt.Errorf("failed to load state of cursor %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package osu
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+	"github.com/wieku/danser-go/app/settings"
+)
+
+func TestStateRoundTrip(t *testing.T) {
+	// late enough to get 100s so that accuracy and hit errors are non-trivial
+	testStateRoundTrip(t, 50)
+}
+
+func TestStateRoundTripCalibrated(t *testing.T) {
+	previous := settings.Gameplay.CalibrationObjects
+	settings.Gameplay.CalibrationObjects = 10
+
+	t.Cleanup(func() {
+		settings.Gameplay.CalibrationObjects = previous
+	})
+
+	// calibration happens before the split, without its offset the resumed run would get 100s
+	testStateRoundTrip(t, -40)
+}
+
+// testStateRoundTrip checks that a run split with SaveState and LoadState at 6000ms gives the same score as a full run
+func testStateRoundTrip(t *testing.T, offset int64) {
+	const split = 6000.0
+
+	full, fullCursor := newTestRuleset(t, loadTestMap(t, "circles.osu"), difficulty.None, offset)
+	full.RunToEnd(1)
+
+	first, _ := newTestRuleset(t, loadTestMap(t, "circles.osu"), difficulty.None, offset)
+	if err := first.RunRange(0, split, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	if err := first.SaveState(path); err != nil {
+		t.Fatal(err)
+	}
+
+	second, secondCursor := newTestRuleset(t, loadTestMap(t, "circles.osu"), difficulty.None, offset)
+	if err := second.LoadState(path); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := second.RunRange(split+1, 1e9, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := full.GetScore(fullCursor)
+	actual := second.GetScore(secondCursor)
+
+	if actual.Score != expected.Score || actual.Combo != expected.Combo {
+		t.Errorf("expected score %d with %dx combo, got %d with %dx", expected.Score, expected.Combo, actual.Score, actual.Combo)
+	}
+
+	if actual.Count300 != expected.Count300 || actual.Count100 != expected.Count100 || actual.Count50 != expected.Count50 || actual.CountMiss != expected.CountMiss {
+		t.Errorf("expected counts %d/%d/%d/%d, got %d/%d/%d/%d", expected.Count300, expected.Count100, expected.Count50, expected.CountMiss, actual.Count300, actual.Count100, actual.Count50, actual.CountMiss)
+	}
+
+	if actual.Accuracy != expected.Accuracy || actual.Grade != expected.Grade {
+		t.Errorf("expected %.2f%% %s, got %.2f%% %s", expected.Accuracy, expected.Grade.String(), actual.Accuracy, actual.Grade.String())
+	}
+
+	if actual.UnstableRate != expected.UnstableRate {
+		t.Errorf("expected %.2f UR, got %.2f", expected.UnstableRate, actual.UnstableRate)
+	}
+
+	if actual, expected := second.GetCalibrationOffset(secondCursor), full.GetCalibrationOffset(fullCursor); actual != expected {
+		t.Errorf("expected %+dms calibration offset, got %+dms", expected, actual)
+	}
+
+	fullSet, secondSet := full.cursors[fullCursor], second.cursors[secondCursor]
+
+	if len(secondSet.timeline) != len(fullSet.timeline) || len(secondSet.presses) != len(fullSet.presses) {
+		t.Errorf("expected %d timeline points and %d presses, got %d and %d", len(fullSet.timeline), len(fullSet.presses), len(secondSet.timeline), len(secondSet.presses))
+	}
+
+	for i := range fullSet.hitMap {
+		if fullSet.hitMap[i] != secondSet.hitMap[i] {
+			t.Errorf("object %d: expected hit %t, got %t", i, fullSet.hitMap[i], secondSet.hitMap[i])
+		}
+	}
+}
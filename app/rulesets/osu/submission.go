@@ -0,0 +1,102 @@
+package osu
+
+import (
+	"fmt"
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+	"github.com/wieku/danser-go/app/graphics"
+	"github.com/wieku/danser-go/app/utils"
+)
+
+// Submission holds data needed to submit a score to a server. Sending it is up to the caller.
+type Submission struct {
+	Username   string  `json:"username"`
+	BeatmapMD5 string  `json:"beatmapMD5"`
+	ReplayMD5  string  `json:"replayMD5,omitempty"`
+	Score      int64   `json:"score"`
+	MaxCombo   uint    `json:"maxCombo"`
+	Perfect    bool    `json:"perfect"`
+	Count300   uint    `json:"count300"`
+	Count100   uint    `json:"count100"`
+	Count50    uint    `json:"count50"`
+	CountGeki  uint    `json:"countGeki"`
+	CountKatu  uint    `json:"countKatu"`
+	CountMiss  uint    `json:"countMiss"`
+	Accuracy   float64 `json:"accuracy"`
+	Grade      string  `json:"grade"`
+	PP         float64 `json:"pp"`
+	Mods       uint    `json:"mods"`
+	ModsString string  `json:"modsString"`
+	Passed     bool    `json:"passed"`
+}
+
+// SetReplayHash sets MD5 of the replay that drives given cursor, it's included in the submission
+func (set *OsuRuleSet) SetReplayHash(cursor *graphics.Cursor, hash string) {
+	set.cursors[cursor].replayHash = hash
+}
+
+// BuildSubmission assembles submission payload from the current score of given cursor
+func (set *OsuRuleSet) BuildSubmission(cursor *graphics.Cursor) Submission {
+	subSet := set.cursors[cursor]
+	score := subSet.score
+
+	return Submission{
+		Username:   cursor.Name,
+		BeatmapMD5: set.beatMap.MD5,
+		ReplayMD5:  subSet.replayHash,
+		Score:      score.Score,
+		MaxCombo:   score.Combo,
+		Perfect:    score.PerfectCombo,
+		Count300:   score.Count300,
+		Count100:   score.Count100,
+		Count50:    score.Count50,
+		CountGeki:  score.CountGeki,
+		CountKatu:  score.CountKatu,
+		CountMiss:  score.CountMiss,
+		Accuracy:   score.Accuracy,
+		Grade:      score.Grade.String(),
+		PP:         score.PP,
+		Mods:       uint(subSet.player.diff.Mods),
+		ModsString: subSet.player.diff.Mods.String(),
+		Passed:     !subSet.failed,
+	}
+}
+
+// GetShareableResults returns a short summary of cursor's current score, suitable for pasting into chat, e.g.:
+//
+//	Artist - Title [Difficulty] +HDDT (6.12*)
+//	98.76% | 1,234/1,500x | 1,200/20/3/1 | 456.78pp | S
+func (set *OsuRuleSet) GetShareableResults(cursor *graphics.Cursor) string {
+	subSet := set.cursors[cursor]
+	score := subSet.score
+
+	mods := subSet.player.diff.GetModString()
+	if mods == "" {
+		mods = "NM"
+	}
+
+	maxCombo := "?"
+	if attribs := set.oppDiffs[difficulty.GetDiffMaskedMods(subSet.player.diff.Mods)]; len(attribs) > 0 {
+		maxCombo = utils.Humanize(attribs[len(attribs)-1].MaxCombo)
+	}
+
+	grade := score.Grade.String()
+	if subSet.failed {
+		grade = "F"
+	}
+
+	header := fmt.Sprintf("%s - %s [%s] +%s (%.2f*)", set.beatMap.Artist, set.beatMap.Name, set.beatMap.Difficulty, mods, set.GetStars(cursor))
+
+	results := fmt.Sprintf("%.2f%% | %s/%sx | %s/%s/%s/%s | %.2fpp | %s",
+		score.Accuracy,
+		utils.Humanize(score.Combo),
+		maxCombo,
+		utils.Humanize(score.Count300),
+		utils.Humanize(score.Count100),
+		utils.Humanize(score.Count50),
+		utils.Humanize(score.CountMiss),
+		score.PP,
+		grade,
+	)
+
+	return header + "\n" + results
+}
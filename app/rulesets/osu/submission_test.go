@@ -0,0 +1,50 @@
+package osu
+
+import (
+	"testing"
+
+	"github.com/wieku/danser-go/app/beatmap/difficulty"
+)
+
+func TestBuildSubmission(t *testing.T) {
+	beatMap := loadTestMap(t, "circles.osu")
+	beatMap.MD5 = "0123456789abcdef0123456789abcdef"
+
+	ruleset, cursors := newScriptedRuleset(t, beatMap, testPlayer{name: "test", mods: difficulty.Hidden, script: skipObjects(0, 5)})
+	ruleset.SetReplayHash(cursors[0], "fedcba9876543210fedcba9876543210")
+	ruleset.RunToEnd(1)
+
+	submission := ruleset.BuildSubmission(cursors[0])
+
+	if submission.PP <= 0 || submission.PP != ruleset.GetScore(cursors[0]).PP {
+		t.Errorf("expected %.2fpp, got %.2fpp", ruleset.GetScore(cursors[0]).PP, submission.PP)
+	}
+
+	submission.PP = 0
+
+	// 5 300s, a miss and 34 300s. With score multiplier 4 and HD's 1.06 the nth circle of a combo gives
+	// 300 + int(300*max(n-1, 0)*4*1.06/25)
+	expected := Submission{
+		Username:   "test",
+		BeatmapMD5: "0123456789abcdef0123456789abcdef",
+		ReplayMD5:  "fedcba9876543210fedcba9876543210",
+		Score:      38852,
+		MaxCombo:   34,
+		Perfect:    false,
+		Count300:   39,
+		Count100:   0,
+		Count50:    0,
+		CountGeki:  0,
+		CountKatu:  0,
+		CountMiss:  1,
+		Accuracy:   97.5,
+		Grade:      "A",
+		Mods:       uint(difficulty.Hidden),
+		ModsString: "HD",
+		Passed:     true,
+	}
+
+	if submission != expected {
+		t.Errorf("expected submission:\n%+v\ngot:\n%+v", expected, submission)
+	}
+}
@@ -0,0 +1,109 @@
+package osu
+
+import (
+	"github.com/wieku/danser-go/app/graphics"
+)
+
+// minTapSectionPresses is the minimum number of presses needed to classify a section
+const minTapSectionPresses = 3
+
+type TapStyle int
+
+const (
+	SingleTap = TapStyle(iota)
+	Alternate
+	MixedTap
+)
+
+func (style TapStyle) String() string {
+	switch style {
+	case SingleTap:
+		return "Single-tap"
+	case Alternate:
+		return "Alternate"
+	default:
+		return "Mixed"
+	}
+}
+
+// TapSection is a run of key presses not further apart than the gap given to GetTapSections
+type TapSection struct {
+	Start, End       int64
+	Presses          int
+	AlternationRatio float64 // Share of presses made with a different key than the previous one
+	Style            TapStyle
+}
+
+type keyPress struct {
+	time    int64
+	buttons Buttons
+}
+
+// recordPress stores new key presses, pressing both keys at once is stored as a single press
+func (subSet *subSet) recordPress(time int64) {
+	var buttons Buttons
+
+	if subSet.player.leftCond {
+		buttons |= Left
+	}
+
+	if subSet.player.rightCond {
+		buttons |= Right
+	}
+
+	if buttons > 0 {
+		subSet.presses = append(subSet.presses, keyPress{time: time, buttons: buttons})
+	}
+}
+
+// GetTapSections splits cursor's key presses into sections where consecutive presses are at most maxGap ms apart
+// and classifies them as alternated (mostly switching keys), single-tapped (mostly the same key) or mixed.
+// Sections with less than 3 presses are omitted.
+func (set *OsuRuleSet) GetTapSections(cursor *graphics.Cursor, maxGap int64) []TapSection {
+	presses := set.cursors[cursor].presses
+
+	sections := make([]TapSection, 0)
+
+	for start := 0; start < len(presses); {
+		end := start + 1
+		for end < len(presses) && presses[end].time-presses[end-1].time <= maxGap {
+			end++
+		}
+
+		if end-start >= minTapSectionPresses {
+			sections = append(sections, classifyTaps(presses[start:end]))
+		}
+
+		start = end
+	}
+
+	return sections
+}
+
+func classifyTaps(presses []keyPress) TapSection {
+	alternations := 0
+
+	for i := 1; i < len(presses); i++ {
+		if presses[i].buttons != presses[i-1].buttons && presses[i].buttons != Left|Right {
+			alternations++
+		}
+	}
+
+	section := TapSection{
+		Start:            presses[0].time,
+		End:              presses[len(presses)-1].time,
+		Presses:          len(presses),
+		AlternationRatio: float64(alternations) / float64(len(presses)-1),
+	}
+
+	switch {
+	case section.AlternationRatio >= 0.75:
+		section.Style = Alternate
+	case section.AlternationRatio <= 0.25:
+		section.Style = SingleTap
+	default:
+		section.Style = MixedTap
+	}
+
+	return section
+}
@@ -0,0 +1,98 @@
+package osu
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+)
+
+// TimelineFrame is score state at a single video frame, values come from the last judgement before frame's time
+type TimelineFrame struct {
+	Frame int `json:"frame"`
+	TimelinePoint
+}
+
+type timelineCursor struct {
+	Name   string          `json:"name"`
+	Frames []TimelineFrame `json:"frames"`
+}
+
+type timelineExport struct {
+	FPS     int              `json:"fps"`
+	Speed   float64          `json:"speed"`
+	Frames  int              `json:"frames"`
+	Cursors []timelineCursor `json:"cursors"`
+}
+
+// GetTimelineFrameCount returns how many frames at given fps are needed to cover the map until the last object's end.
+// Frames are in real time, so speed changing mods change the count.
+func (set *OsuRuleSet) GetTimelineFrameCount(fps int) int {
+	objs := set.beatMap.HitObjects
+	if fps <= 0 || len(objs) == 0 {
+		return 0
+	}
+
+	duration := objs[len(objs)-1].GetEndTime() / set.beatMap.Diff.Speed
+
+	return int(math.Ceil(duration*float64(fps)/1000)) + 1
+}
+
+// ExportTimeline samples score timelines of all cursors at given frame rate and saves them as JSON, so external tools
+// can render an overlay synced to the video. Frame n is at n*1000/fps milliseconds of real time from the beginning of
+// the map. Cursors are saved in the order they were passed to NewOsuRuleset, as names don't have to be unique.
+// It should be called after the ruleset finished, e.g. after RunToEnd.
+//
+// Frames are sampled from recorded timelines, so with Gameplay.ScoreTimelineInterval above the frame time some
+// judgements are merged and a frame shows the last recorded point instead of the exact state at its time.
+func (set *OsuRuleSet) ExportTimeline(path string, fps int) error {
+	if fps <= 0 {
+		return fmt.Errorf("invalid frame rate: %d", fps)
+	}
+
+	frameCount := set.GetTimelineFrameCount(fps)
+	if frameCount == 0 {
+		return errors.New("beatmap has no objects")
+	}
+
+	export := timelineExport{
+		FPS:     fps,
+		Speed:   set.beatMap.Diff.Speed,
+		Frames:  frameCount,
+		Cursors: make([]timelineCursor, 0, len(set.cursorOrder)),
+	}
+
+	for _, cursor := range set.cursorOrder {
+		subSet := set.cursors[cursor]
+
+		frames := make([]TimelineFrame, frameCount)
+
+		current := TimelinePoint{Accuracy: 100}
+		index := 0
+
+		for i := range frames {
+			time := int64(float64(i) * 1000 / float64(fps) * set.beatMap.Diff.Speed)
+
+			for ; index < len(subSet.timeline) && subSet.timeline[index].Time <= time; index++ {
+				current = subSet.timeline[index]
+			}
+
+			frames[i] = TimelineFrame{Frame: i, TimelinePoint: current}
+			frames[i].Time = time
+		}
+
+		export.Cursors = append(export.Cursors, timelineCursor{Name: cursor.Name, Frames: frames})
+	}
+
+	data, err := json.Marshal(export)
+	if err != nil {
+		return fmt.Errorf("failed to serialize timeline: %w", err)
+	}
+
+	if err = os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to save timeline: %w", err)
+	}
+
+	return nil
+}
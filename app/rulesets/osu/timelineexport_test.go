@@ -0,0 +1,60 @@
+package osu
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportTimeline(t *testing.T) {
+	beatMap := loadTestMap(t, "circles.osu")
+
+	// names are the same on purpose, cursors have to be told apart by their order
+	ruleset, _ := newScriptedRuleset(t, beatMap,
+		testPlayer{name: "test", script: clickAll(0)},
+		testPlayer{name: "test", script: skipObjects(0, 5)},
+	)
+	ruleset.RunToEnd(1)
+
+	path := filepath.Join(t.TempDir(), "timeline.json")
+
+	if err := ruleset.ExportTimeline(path, 60); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var export timelineExport
+
+	if err = json.Unmarshal(data, &export); err != nil {
+		t.Fatal(err)
+	}
+
+	// the last object ends at 12700ms: ceil(12.7s * 60fps) frames plus the frame at 0ms
+	const frameCount = 763
+
+	if export.Frames != frameCount {
+		t.Errorf("expected %d frames, got %d", frameCount, export.Frames)
+	}
+
+	if len(export.Cursors) != 2 {
+		t.Fatalf("expected 2 cursors, got %d", len(export.Cursors))
+	}
+
+	for i, expectedCombo := range []int{40, 34} {
+		frames := export.Cursors[i].Frames
+
+		if len(frames) != frameCount {
+			t.Errorf("cursor %d: expected %d frames, got %d", i, frameCount, len(frames))
+			continue
+		}
+
+		if last := frames[len(frames)-1]; last.Combo != expectedCombo {
+			t.Errorf("cursor %d: expected %dx combo in the last frame, got %dx", i, expectedCombo, last.Combo)
+		}
+	}
+}
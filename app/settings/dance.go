@@ -78,30 +78,38 @@ func (d *defaultsFactory) InitSpline() *spline {
 }
 
 type momentum struct {
-	SkipStackAngles bool
-	StreamRestrict  bool
-	DurationMult    float64 `max:"8"`
-	DurationTrigger float64 `max:"4000" format:"%.0fms"`
-	StreamMult      float64 `min:"-10" max:"10"`
-	RestrictAngle   float64 `min:"0" max:"180" format:"%.0f°"`
-	RestrictArea    float64 `min:"0" max:"180" format:"%.0f°"`
-	RestrictInvert  bool
-	DistanceMult    float64 `min:"-4" max:"4"`
-	DistanceMultOut float64 `min:"-4" max:"4"`
+	SkipStackAngles    bool
+	StreamRestrict     bool
+	DurationMult       float64 `max:"8"`
+	DurationTrigger    float64 `max:"4000" format:"%.0fms"`
+	StreamMult         float64 `min:"-10" max:"10"`
+	RestrictAngle      float64 `min:"0" max:"180" format:"%.0f°"`
+	RestrictArea       float64 `min:"0" max:"180" format:"%.0f°"`
+	RestrictInvert     bool
+	DistanceMult       float64 `min:"-4" max:"4"`
+	DistanceMultOut    float64 `min:"-4" max:"4"`
+	Overshoot          float64 `max:"1" scale:"100" format:"%.0f%%" tooltip:"How far past the target the cursor overshoots on jumps, relative to the part of jump distance above the threshold. 0 disables it"`
+	OvershootDecay     float64 `max:"10" tooltip:"How quickly the overshoot fades with more time between objects"`
+	OvershootThreshold float64 `max:"512" format:"%.0fo!px" tooltip:"Only jumps longer than this overshoot"`
+	GroupLookahead     int     `max:"2" label:"Stream group lookahead" tooltip:"Up to this many more objects of an evenly spaced stream are joined into a single smooth curve. 0 moves between pairs of objects"`
 }
 
 func (d *defaultsFactory) InitMomentum() *momentum {
 	return &momentum{
-		SkipStackAngles: false,
-		StreamRestrict:  true,
-		StreamMult:      0.7,
-		DurationMult:    2,
-		DurationTrigger: 500,
-		RestrictAngle:   90,
-		RestrictArea:    40,
-		RestrictInvert:  true,
-		DistanceMult:    0.6,
-		DistanceMultOut: 0.45,
+		SkipStackAngles:    false,
+		StreamRestrict:     true,
+		StreamMult:         0.7,
+		DurationMult:       2,
+		DurationTrigger:    500,
+		RestrictAngle:      90,
+		RestrictArea:       40,
+		RestrictInvert:     true,
+		DistanceMult:       0.6,
+		DistanceMultOut:    0.45,
+		GroupLookahead:     0,
+		Overshoot:          0,
+		OvershootDecay:     2,
+		OvershootThreshold: 150,
 	}
 }
 
@@ -14,7 +14,8 @@ func initCursorDance() *cursorDance {
 		Battle:             false,
 		DoSpinnersTogether: true,
 		TAGSliderDance:     false,
-		MoverSettings: &moverSettings{
+		Presets:            []*MoverPreset{},
+		MoverSettings: &MoverSettings{
 			Bezier: []*bezier{
 				DefaultsFactory.InitBezier(),
 			},
@@ -45,6 +46,7 @@ func initCursorDance() *cursorDance {
 
 type mover struct {
 	Mover             string `combo:"spline,bezier,circular,linear,axis,aggressive,flower,momentum,exgon,pippi"`
+	Preset            string `tooltip:"Name of a mover preset, overrides mover above. Built-in presets: snappy, flowy, aggressive" liveedit:"false"`
 	SliderDance       bool
 	RandomSliderDance bool
 }
@@ -52,6 +54,7 @@ type mover struct {
 func (d *defaultsFactory) InitMover() *mover {
 	return &mover{
 		Mover:             "spline",
+		Preset:            "",
 		SliderDance:       false,
 		RandomSliderDance: false,
 	}
@@ -63,12 +66,16 @@ type spinner struct {
 	CenterOffsetX float64 `min:"-1000" max:"1000"`
 	CenterOffsetY float64 `min:"-1000" max:"1000"`
 	Radius        float64 `max:"200" format:"%.0fo!px"`
+	SpinSpeed     string  `combo:"max|Max RPM,clear|Just enough to clear,custom|Custom" tooltip:"How fast autoplay spins. Applies only to circle and heart movers"`
+	RPM           float64 `min:"60" max:"477" format:"%.0f RPM" showif:"SpinSpeed=custom"`
 }
 
 func (d *defaultsFactory) InitSpinner() *spinner {
 	return &spinner{
-		Mover:  "circle",
-		Radius: 100,
+		Mover:     "circle",
+		Radius:    100,
+		SpinSpeed: "max",
+		RPM:       477,
 	}
 }
 
@@ -79,10 +86,11 @@ type cursorDance struct {
 	Battle             bool       `liveedit:"false"`
 	DoSpinnersTogether bool       `liveedit:"false"`
 	TAGSliderDance     bool       `label:"TAG slider dance" liveedit:"false"`
-	MoverSettings      *moverSettings
+	MoverSettings      *MoverSettings
+	Presets            []*MoverPreset `skip:"true"`
 }
 
-type moverSettings struct {
+type MoverSettings struct {
 	Bezier     []*bezier   `new:"InitBezier"`
 	Flower     []*flower   `new:"InitFlower"`
 	HalfCircle []*circular `new:"InitCircular"`
@@ -0,0 +1,117 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// MoverPreset sets a base mover with parameters overriding its defaults. Params use the same names as MoverSettings fields.
+type MoverPreset struct {
+	Name   string
+	Mover  string
+	Params map[string]interface{}
+}
+
+var builtInPresets = []*MoverPreset{
+	{
+		Name:  "snappy",
+		Mover: "linear",
+		Params: map[string]interface{}{
+			"WaitForPreempt": false,
+			"ReactionTime":   60,
+		},
+	},
+	{
+		Name:  "flowy",
+		Mover: "momentum",
+		Params: map[string]interface{}{
+			"DurationMult":    3,
+			"StreamMult":      0.9,
+			"DistanceMult":    0.8,
+			"DistanceMultOut": 0.6,
+		},
+	},
+	{
+		Name:  "aggressive",
+		Mover: "bezier",
+		Params: map[string]interface{}{
+			"Aggressiveness":       120,
+			"SliderAggressiveness": 8,
+		},
+	},
+}
+
+// GetMoverPreset finds a preset by name, user presets take precedence over built-in ones
+func (c *cursorDance) GetMoverPreset(name string) *MoverPreset {
+	for _, presets := range [][]*MoverPreset{c.Presets, builtInPresets} {
+		for _, p := range presets {
+			if strings.EqualFold(p.Name, name) {
+				return p
+			}
+		}
+	}
+
+	return nil
+}
+
+// NewPresetMoverSettings returns mover name and settings described by the preset. Settings are separate from
+// MoverSettings so they are never saved to the config, the mover should use them with id 0.
+// Settings are nil if the mover doesn't have any.
+func (c *cursorDance) NewPresetMoverSettings(name string) (mover string, ms *MoverSettings, err error) {
+	preset := c.GetMoverPreset(name)
+	if preset == nil {
+		return "", nil, fmt.Errorf("unknown mover preset: %s", name)
+	}
+
+	mover = strings.ToLower(preset.Mover)
+
+	ms = &MoverSettings{}
+
+	var target interface{}
+
+	switch mover {
+	case "bezier":
+		s := DefaultsFactory.InitBezier()
+		ms.Bezier, target = []*bezier{s}, s
+	case "flower":
+		s := DefaultsFactory.InitFlower()
+		ms.Flower, target = []*flower{s}, s
+	case "circular":
+		s := DefaultsFactory.InitCircular()
+		ms.HalfCircle, target = []*circular{s}, s
+	case "spline":
+		s := DefaultsFactory.InitSpline()
+		ms.Spline, target = []*spline{s}, s
+	case "momentum":
+		s := DefaultsFactory.InitMomentum()
+		ms.Momentum, target = []*momentum{s}, s
+	case "exgon":
+		s := DefaultsFactory.InitExGon()
+		ms.ExGon, target = []*exgon{s}, s
+	case "linear":
+		s := DefaultsFactory.InitLinear()
+		ms.Linear, target = []*linear{s}, s
+	case "pippi":
+		s := DefaultsFactory.InitPippi()
+		ms.Pippi, target = []*pippi{s}, s
+	default:
+		if len(preset.Params) > 0 {
+			return "", nil, fmt.Errorf("mover preset %s: %s mover doesn't have parameters", preset.Name, preset.Mover)
+		}
+
+		// mover doesn't use settings
+		return mover, nil, nil
+	}
+
+	data, err := json.Marshal(preset.Params)
+	if err == nil {
+		err = json.Unmarshal(data, target)
+	}
+
+	if err != nil {
+		return "", nil, fmt.Errorf("mover preset %s: invalid parameters: %w", preset.Name, err)
+	}
+
+	return mover, ms, nil
+}
@@ -0,0 +1,47 @@
+package settings
+
+import "testing"
+
+func TestNewPresetMoverSettings(t *testing.T) {
+	linearCount := len(CursorDance.MoverSettings.Linear)
+
+	for i := 0; i < 2; i++ {
+		mover, ms, err := CursorDance.NewPresetMoverSettings("snappy")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if mover != "linear" {
+			t.Fatalf("expected linear mover, got %s", mover)
+		}
+
+		if len(ms.Linear) != 1 {
+			t.Fatalf("expected 1 linear settings entry, got %d", len(ms.Linear))
+		}
+
+		if config := ms.Linear[0]; config.WaitForPreempt || config.ReactionTime != 60 {
+			t.Errorf("preset parameters not applied: WaitForPreempt: %t, ReactionTime: %.0f", config.WaitForPreempt, config.ReactionTime)
+		}
+	}
+
+	if len(CursorDance.MoverSettings.Linear) != linearCount {
+		t.Errorf("global linear settings changed from %d to %d entries", linearCount, len(CursorDance.MoverSettings.Linear))
+	}
+
+	if CursorDance.MoverSettings.Linear[0].ReactionTime == 60 {
+		t.Error("preset parameters leaked into global settings")
+	}
+}
+
+func TestNewPresetMoverSettingsErrors(t *testing.T) {
+	if _, _, err := CursorDance.NewPresetMoverSettings("missing"); err == nil {
+		t.Error("expected error for unknown preset")
+	}
+
+	CursorDance.Presets = []*MoverPreset{{Name: "broken", Mover: "linear", Params: map[string]interface{}{"ReactionTime": "fast"}}}
+	t.Cleanup(func() { CursorDance.Presets = []*MoverPreset{} })
+
+	if _, _, err := CursorDance.NewPresetMoverSettings("broken"); err == nil {
+		t.Error("expected error for invalid parameters")
+	}
+}
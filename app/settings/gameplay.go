@@ -1,5 +1,10 @@
 package settings
 
+import (
+	"strconv"
+	"strings"
+)
+
 var Gameplay = initGameplay()
 
 func initGameplay() *gameplay {
@@ -77,7 +82,13 @@ func initGameplay() *gameplay {
 				XOffset: 0,
 				YOffset: 0,
 			},
-			Static: false,
+			Static:           false,
+			PopInEasing:      "InQuad",
+			PopOutEasing:     "OutQuad",
+			PopScale:         1.094,
+			PopDuration:      100,
+			MinCombo:         0,
+			RollbackDuration: 0,
 		},
 		PPCounter: &ppCounter{
 			hudElementPosition: &hudElementPosition{
@@ -95,11 +106,31 @@ func initGameplay() *gameplay {
 				Value:      1,
 			},
 			Decimals:         0,
+			RoundLive:        false,
+			Goal:             0,
 			Align:            "CentreLeft",
 			ShowInResults:    true,
 			ShowPPComponents: false,
 			Static:           false,
 		},
+		BPMDisplay: &bpmDisplay{
+			hudElementPosition: &hudElementPosition{
+				hudElement: &hudElement{
+					Show:    false,
+					Scale:   1.0,
+					Opacity: 1.0,
+				},
+				XPosition: 5,
+				YPosition: 200,
+			},
+			Color: &HSV{
+				Hue:        0,
+				Saturation: 0,
+				Value:      1,
+			},
+			Align:       "CentreLeft",
+			ShowMainBPM: true,
+		},
 		HitCounter: &hitCounter{
 			hudElementPosition: &hudElementPosition{
 				hudElement: &hudElement{
@@ -168,14 +199,17 @@ func initGameplay() *gameplay {
 				InnerOpacity:  0.5,
 			},
 		},
-		KeyOverlay: &hudElementOffset{
-			hudElement: &hudElement{
-				Show:    true,
-				Scale:   1.0,
-				Opacity: 1.0,
+		KeyOverlay: &keyOverlay{
+			hudElementOffset: &hudElementOffset{
+				hudElement: &hudElement{
+					Show:    true,
+					Scale:   1.0,
+					Opacity: 1.0,
+				},
+				XOffset: 0,
+				YOffset: 0,
 			},
-			XOffset: 0,
-			YOffset: 0,
+			MouseOnTop: false,
 		},
 		ScoreBoard: &scoreBoard{
 			hudElementOffset: &hudElementOffset{
@@ -228,16 +262,73 @@ func initGameplay() *gameplay {
 			Path:       "",
 			AboveHpBar: false,
 		},
+		MissFlash: &missFlash{
+			Enabled:   false,
+			Intensity: 0.15,
+			Duration:  300,
+		},
+		HUDEndFade: &hudEndFade{
+			Enabled:  false,
+			Duration: 1000,
+		},
+		SliderTickFeedback: &sliderTickFeedback{
+			Pulse:  false,
+			Sound:  false,
+			Volume: 0.5,
+		},
+		HUDDimOpacity: &hudDimOpacity{
+			Enabled:    false,
+			MinOpacity: 0.6,
+			MaxOpacity: 1,
+		},
+		OneLife: &oneLife{
+			Enabled:           false,
+			FailOnSliderBreak: false,
+			FailOn50:          false,
+		},
+		Practice: &practice{
+			Filter:           "All",
+			SpacingThreshold: 100,
+		},
+		HitWindows: &hitWindows{
+			Enabled: false,
+			Hit300:  80,
+			Hit100:  140,
+			Hit50:   200,
+		},
+		HUDAspectRatio:          "Screen",
 		HUDFont:                 "",
 		ShowResultsScreen:       true,
 		ResultsScreenTime:       5,
 		ResultsUseLocalTimeZone: false,
 		ShowWarningArrows:       true,
 		ShowHitLighting:         false,
+		ShowHitOffset:           false,
+		ShowSpinnerProgress:     false,
+		ScaleResultsWithSpeed:   false,
+		KiaiEffects:             false,
 		FlashlightDim:           1,
 		PlayUsername:            "Guest",
 		IgnoreFailsInReplays:    false,
+		ReplayEndLeniency:       1,
+		TruncatedReplayWindow:   0,
+		Tolerance2B:             3,
 		UseLazerPP:              false,
+		StarRatingSource:        "pp220930",
+		ConvertMode:             "osu",
+		CompareComboScaling:     false,
+		TimingBiasThreshold:     3,
+		PoolResultSprites:       true,
+		ScoreTimelineInterval:   0,
+		SliderTrackingRadius:    1,
+		CalibrationObjects:      0,
+		InputLatency:            0,
+		EarlyClicksMiss:         false,
+		EarlyMissWindow:         200,
+		PPBlend:                 1,
+		RecordingPPInterval:     1,
+		TintResultsWithCombo:    false,
+		LowerImpossibleDrain:    false,
 	}
 }
 
@@ -248,23 +339,70 @@ type gameplay struct {
 	HpBar                   *hudElementOffset
 	ComboCounter            *comboCounter
 	PPCounter               *ppCounter
+	BPMDisplay              *bpmDisplay `label:"BPM display"`
 	HitCounter              *hitCounter
 	StrainGraph             *strainGraph
-	KeyOverlay              *hudElementOffset
+	KeyOverlay              *keyOverlay
 	ScoreBoard              *scoreBoard
 	Mods                    *mods
 	Boundaries              *boundaries
 	Underlay                *underlay
-	HUDFont                 string  `label:"Overlay (HUD) font" file:"Select HUD font" filter:"TrueType/OpenType Font (*.ttf, *.otf)|ttf,otf" tooltip:"Sets the font that will be used for PP/UR/hit counts" liveedit:"false"`
-	ShowResultsScreen       bool    `liveedit:"false"`
-	ResultsScreenTime       float64 `label:"Results screen duration" min:"1" max:"20" format:"%.1fs" liveedit:"false"`
-	ResultsUseLocalTimeZone bool    `label:"Show PC's time zone instead of UTC"`
+	MissFlash               *missFlash
+	HUDEndFade              *hudEndFade         `label:"HUD fade-out at map end"`
+	SliderTickFeedback      *sliderTickFeedback `label:"Slider tick feedback"`
+	HUDDimOpacity           *hudDimOpacity      `label:"HUD opacity by background dim"`
+	OneLife                 *oneLife            `label:"1-life mode"`
+	Practice                *practice
+	HitWindows              *hitWindows `label:"Custom hit windows"`
+	HUDAspectRatio          string      `label:"HUD aspect ratio" combo:"Screen,4:3,16:9,21:9" tooltip:"Lays out the HUD for given aspect ratio, it's pillarboxed on wider screens and letterboxed on narrower ones" liveedit:"false"`
+	HUDFont                 string      `label:"Overlay (HUD) font" file:"Select HUD font" filter:"TrueType/OpenType Font (*.ttf, *.otf)|ttf,otf" tooltip:"Sets the font that will be used for PP/UR/hit counts" liveedit:"false"`
+	ShowResultsScreen       bool        `liveedit:"false"`
+	ResultsScreenTime       float64     `label:"Results screen duration" min:"1" max:"20" format:"%.1fs" liveedit:"false"`
+	ResultsUseLocalTimeZone bool        `label:"Show PC's time zone instead of UTC"`
 	ShowWarningArrows       bool
 	ShowHitLighting         bool
+	ShowHitOffset           bool `label:"Show hit offset on 100s and 50s" tooltip:"Draws signed hit offset in ms next to 100 and 50 judgements, blue when early and red when late"`
+	TintResultsWithCombo    bool `label:"Tint 100s and 50s with combo color"`
+	ScaleResultsWithSpeed   bool `label:"Keep judgement duration constant with speed mods" tooltip:"Judgement sprites stay on screen for the same real time with DT/HT, by default they follow game time"`
+	KiaiEffects             bool `label:"Pulse screen border during kiai" tooltip:"Draws a subtle border glow synced to beats in kiai sections"`
+	ShowSpinnerProgress     bool `label:"Show spinner progress" tooltip:"Shows spin count needed to clear the spinner and awarded bonus while spinning"`
 	FlashlightDim           float64
 	PlayUsername            string `liveedit:"false"`
 	IgnoreFailsInReplays    bool
-	UseLazerPP              bool `liveedit:"false" skip:"true"`
+	ReplayEndLeniency       int64   `label:"Replay end leniency" min:"0" max:"1000" format:"%dms" tooltip:"Replays ending this much before the last object's end won't be marked as failed. Useful for replays with truncated frames"`
+	TruncatedReplayWindow   int64   `label:"Truncated replay window" min:"0" max:"30000" format:"%dms" tooltip:"Analysis only! Replays ending within this time before the last object's end aren't failed, objects after the replay's end are missed instead. 0 disables it" liveedit:"false"`
+	Tolerance2B             int64   `label:"2B notelock tolerance" min:"0" max:"200" format:"%dms" tooltip:"Unhit objects ending less than this before the clicked object's start don't notelock it, which helps on 2B maps. 3ms matches osu!, higher values are for analysis only" liveedit:"false"`
+	UseLazerPP              bool    `liveedit:"false" skip:"true"`
+	StarRatingSource        string  `label:"Star rating algorithm" combo:"pp220930|danser (pp220930),rosuPP|rosu-pp (FFI)" tooltip:"Which star rating is shown in results, both algorithms can differ slightly" liveedit:"false"`
+	ConvertMode             string  `label:"Star rating and pp mode" combo:"osu|osu!,taiko|osu!taiko,catch|osu!catch,mania|osu!mania" tooltip:"Analysis only! Game mode rosu-pp converts osu! maps to. Objects are still judged as osu! ones, so other modes show stars only. pp of a perfect play is shown separately on the results screen and is never awarded to the score" liveedit:"false"`
+	PPBlend                 float64 `label:"rosu-pp weight in displayed pp" min:"0" max:"1" scale:"100" format:"%.0f%%" tooltip:"Displayed pp is blended between rosu-pp (FFI) and danser's pp220930 implementation. 100% shows rosu-pp only" liveedit:"false"`
+	RecordingPPInterval     int     `label:"rosu-pp interval while recording" min:"1" max:"50" tooltip:"While recording, rosu-pp is called only every Nth judged object to keep frame times stable. Displayed pp is approximate in between, final pp is always exact" liveedit:"false"`
+	CompareComboScaling     bool    `label:"Calculate pp with and without combo scaling" tooltip:"Calculates stable-style pp (with classic combo scaling) and lazer-style pp (without it) and shows both in the results table" liveedit:"false"`
+	TimingBiasThreshold     float64 `label:"Offset suggestion threshold" min:"0" max:"50" format:"%.0fms" tooltip:"Suggest an offset change at the end of the map if mean hit error is at least this far from 0"`
+	ScoreTimelineInterval   int64   `label:"Score timeline resolution" min:"0" max:"1000" format:"%dms" tooltip:"Minimum time between recorded score timeline points, 0 records every judgement. Higher values reduce memory usage on long maps" liveedit:"false"`
+	SliderTrackingRadius    float64 `label:"Slider tracking radius" min:"0.5" max:"1" scale:"100" format:"%.0f%%" tooltip:"Scales the radius in which the cursor has to stay to keep tracking a slider. 100% is stable behaviour, lower values make tracking stricter" liveedit:"false"`
+	PoolResultSprites       bool    `label:"Reuse judgement sprites" tooltip:"Reuses particle sprites of finished judgements, reduces memory churn on dense maps" liveedit:"false"`
+	LowerImpossibleDrain    bool    `label:"Lower HP drain on unpassable maps" tooltip:"Visualization only! If even an SS would fail because of HP drain, passive drain is lowered for autoplay so it can finish the map" liveedit:"false"`
+	EarlyClicksMiss         bool    `label:"Too early clicks are misses" tooltip:"Analysis only! Clicks shortly before osu!'s hittable range (400ms) miss the object instead of shaking it like osu! and lazer do. Notelock still shakes. Scores won't match osu!" liveedit:"false"`
+	EarlyMissWindow         float64 `label:"Early miss window" min:"0" max:"1000" format:"%.0fms" showif:"EarlyClicksMiss=true" tooltip:"How much earlier than osu!'s hittable range a click can be to still miss the object, clicks even earlier shake it" liveedit:"false"`
+	CalibrationObjects      int     `label:"Auto-calibration objects" min:"0" max:"200" tooltip:"Analysis only! After this many judged objects, their mean hit error is applied as an offset to the rest of the map. Scores won't match osu!, unstable rate and timing bias are measured after calibration. 0 disables calibration" liveedit:"false"`
+	InputLatency            int64   `label:"Input latency compensation" min:"0" max:"200" format:"%dms" tooltip:"Live play only! Your clicks are judged this much earlier to compensate for display and input lag. Unlike audio offset it doesn't move the music or objects, and unlike replay offsets it's never applied to replays" liveedit:"false"`
+}
+
+// GetHUDAspectRatio returns aspect ratio HUD should be laid out in, screen aspect ratio is used if it's not set or invalid
+func (g *gameplay) GetHUDAspectRatio() float64 {
+	parts := strings.Split(g.HUDAspectRatio, ":")
+
+	if len(parts) == 2 {
+		w, err1 := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		h, err2 := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+
+		if err1 == nil && err2 == nil && w > 0 && h > 0 {
+			return w / h
+		}
+	}
+
+	return Graphics.GetAspectRatio()
 }
 
 type boundaries struct {
@@ -286,6 +424,11 @@ type hudElement struct {
 	Opacity float64 `scale:"100.0" format:"%.0f%%"`
 }
 
+type keyOverlay struct {
+	*hudElementOffset
+	MouseOnTop bool `label:"Show M1/M2 above K1/K2" liveedit:"false"`
+}
+
 type hudElementOffset struct {
 	*hudElement
 	offset  string  `vector:"true" left:"XOffset" right:"YOffset"`
@@ -335,19 +478,34 @@ type score struct {
 
 type comboCounter struct {
 	*hudElementOffset
-	Static bool
+	Static           bool
+	PopInEasing      string  `label:"Pop-in easing" combo:"Linear,InQuad,OutQuad,InOutQuad,InCubic,OutCubic,InOutCubic,InSine,OutSine,InOutSine,InExpo,OutExpo,InBack,OutBack,OutElastic,OutBounce" showif:"Static=false"`
+	PopOutEasing     string  `label:"Pop-out easing" combo:"Linear,InQuad,OutQuad,InOutQuad,InCubic,OutCubic,InOutCubic,InSine,OutSine,InOutSine,InExpo,OutExpo,InBack,OutBack,OutElastic,OutBounce" showif:"Static=false"`
+	PopScale         float64 `label:"Pop scale" min:"1" max:"2" format:"%.3fx" showif:"Static=false"`
+	PopDuration      float64 `label:"Pop duration" min:"20" max:"500" format:"%.0fms" showif:"Static=false"`
+	RollbackDuration float64 `label:"Combo break rollback duration" min:"0" max:"2000" format:"%.0fms" tooltip:"Counts the combo down to 0 over this time after a combo break. 0 keeps default behaviour"`
+	MinCombo         int     `label:"Minimum combo to show" min:"0" max:"100" tooltip:"Combo counter is hidden below this combo, 0 always shows it"`
 }
 
 type ppCounter struct {
 	*hudElementPosition
-	Color            *HSV   `short:"true"`
-	Decimals         int    `max:"5"`
-	Align            string `combo:"TopLeft,Top,TopRight,Left,Centre,Right,BottomLeft,Bottom,BottomRight"`
+	Color            *HSV    `short:"true"`
+	Decimals         int     `max:"5"`
+	RoundLive        bool    `label:"Round pp during play" tooltip:"Shows whole pp while playing, results screen shows 2 decimal places"`
+	Goal             float64 `label:"PP goal" min:"0" max:"2000" format:"%.0fpp" tooltip:"Shows remaining pp to the goal under the counter, turns green when it's reached. 0 hides it"`
+	Align            string  `combo:"TopLeft,Top,TopRight,Left,Centre,Right,BottomLeft,Bottom,BottomRight"`
 	ShowInResults    bool
 	ShowPPComponents bool `label:"Show PP breakdown"`
 	Static           bool
 }
 
+type bpmDisplay struct {
+	*hudElementPosition
+	Color       *HSV   `short:"true"`
+	Align       string `combo:"TopLeft,Top,TopRight,Left,Centre,Right,BottomLeft,Bottom,BottomRight"`
+	ShowMainBPM bool   `label:"Show map's main BPM" tooltip:"Shows the BPM that lasts the longest next to the current one if they differ"`
+}
+
 type hitCounter struct {
 	*hudElementPosition
 	Color            []*HSV  `json:",omitempty" new:"InitHSV" label:"Color list" skip:"true"`
@@ -408,6 +566,47 @@ type outline struct {
 	InnerOpacity  float64 `scale:"100.0" format:"%.0f%%" tooltip:"Opacity of filled shape, only applicable when DrawOutline is enabled"`
 }
 
+type missFlash struct {
+	Enabled   bool    `label:"Flash the screen on miss"`
+	Intensity float64 `scale:"100.0" format:"%.0f%%" showif:"Enabled=true"`
+	Duration  float64 `min:"50" max:"1000" format:"%.0fms" showif:"Enabled=true"`
+}
+
+type sliderTickFeedback struct {
+	Pulse  bool    `tooltip:"Shows a small pulse when a slider tick is hit"`
+	Sound  bool    `tooltip:"Plays skin's slidertick-feedback sample (or normal-slidertick if it's missing) when a slider tick is hit"`
+	Volume float64 `min:"0" max:"1" scale:"100" format:"%.0f%%" showif:"Sound=true"`
+}
+
+type hudDimOpacity struct {
+	Enabled    bool    `tooltip:"Makes HUD more opaque over brighter (less dimmed) backgrounds and more transparent over darker ones. Uses normal background dim"`
+	MinOpacity float64 `label:"Opacity at 100% dim" min:"0" max:"1" scale:"100" format:"%.0f%%" showif:"Enabled=true"`
+	MaxOpacity float64 `label:"Opacity at 0% dim" min:"0" max:"1" scale:"100" format:"%.0f%%" showif:"Enabled=true"`
+}
+
+type hudEndFade struct {
+	Enabled  bool    `tooltip:"Fades out HUD after the last object is judged, results screen is not affected"`
+	Duration float64 `min:"100" max:"5000" format:"%.0fms" showif:"Enabled=true"`
+}
+
+type oneLife struct {
+	Enabled           bool `tooltip:"Any miss immediately fails the run, ignores NoFail and EZ recoveries"`
+	FailOnSliderBreak bool `label:"Fail on slider breaks" showif:"Enabled=true"`
+	FailOn50          bool `label:"Fail on 50s" showif:"Enabled=true"`
+}
+
+type hitWindows struct {
+	Enabled bool  `tooltip:"Analysis only! Overrides OD-derived hit windows used for judgement. Scores won't match osu!" liveedit:"false"`
+	Hit300  int64 `label:"300 window" min:"1" max:"400" format:"%dms" showif:"Enabled=true" liveedit:"false"`
+	Hit100  int64 `label:"100 window" min:"1" max:"400" format:"%dms" showif:"Enabled=true" liveedit:"false"`
+	Hit50   int64 `label:"50 window" min:"1" max:"400" format:"%dms" showif:"Enabled=true" liveedit:"false"`
+}
+
+type practice struct {
+	Filter           string  `label:"Object filter" combo:"All|All objects,Sliders|Sliders only,Jumps|Jumps only,Streams|Streams only" tooltip:"Only the selected objects are judged, the rest are skipped and don't affect score. pp isn't calculated and you can't fail while filtering" liveedit:"false"`
+	SpacingThreshold float64 `label:"Jump/stream spacing threshold" min:"0" max:"512" format:"%.0f o!px" tooltip:"Objects further than this from the previous object count as jumps, the rest as streams" showif:"Filter=Jumps,Streams" liveedit:"false"`
+}
+
 type underlay struct {
 	Path       string `file:"Select underlay image" filter:"PNG file (*.png)|png" tooltip:"PNG file that will be used as HUD background (similar to custom HP bar backgrounds). It's scaled automatically to fit the screen vertically" liveedit:"false"`
 	AboveHpBar bool   `label:"Show underlay above HP bar" tooltip:"Use this if HP bar background is large"`
@@ -17,3 +17,4 @@ var TAG = 1
 var RECORD = false
 var REPLAY = ""
 var LOCALOFFSET = 0
+var CONTROL = false
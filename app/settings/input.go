@@ -12,6 +12,7 @@ func initInput() *input {
 		MouseButtonsDisabled: true,
 		MouseHighPrecision:   false,
 		MouseSensitivity:     1,
+		PauseOnFocusLoss:     false,
 	}
 }
 
@@ -24,4 +25,5 @@ type input struct {
 	MouseButtonsDisabled bool    `label:"Disable mouse buttons"`
 	MouseHighPrecision   bool    `label:"Mouse raw input"`
 	MouseSensitivity     float64 `label:"Raw input sensitivity" min:"0.4" max:"6"`
+	PauseOnFocusLoss     bool    `label:"Pause when window loses focus" tooltip:"Works only while playing, never while recording"`
 }
@@ -7,9 +7,13 @@ func initObjects() *objects {
 		DrawApproachCircles: true,
 		DrawComboNumbers:    true,
 		DrawFollowPoints:    true,
+		MaxApproaching:      0,
 		LoadSpinners:        true,
 		ScaleToTheBeat:      false,
 		StackEnabled:        true,
+		StackLeniency:       -1,
+		MalformedSliders:    "Keep",
+		HiddenFadeFraction:  0.7,
 		Sliders: &sliders{
 			ForceSliderBallTexture: true,
 			DrawEndCircles:         true,
@@ -102,9 +106,13 @@ type objects struct {
 	DrawApproachCircles bool //true
 	DrawComboNumbers    bool
 	DrawFollowPoints    bool
-	LoadSpinners        bool `liveedit:"false"`
-	ScaleToTheBeat      bool //true, objects size is changing with music peak amplitude
-	StackEnabled        bool `label:"Enable stack leniency" liveedit:"false"` //true, stack leniency
+	MaxApproaching      int     `label:"Max approaching objects" max:"20" tooltip:"Limits how many not yet hit objects are drawn at once, 0 means no limit. Doesn't affect scoring"`
+	LoadSpinners        bool    `liveedit:"false"`
+	ScaleToTheBeat      bool    //true, objects size is changing with music peak amplitude
+	StackEnabled        bool    `label:"Enable stack leniency" liveedit:"false"` //true, stack leniency
+	StackLeniency       float64 `label:"Stack leniency override" min:"-1" max:"1" format:"%.2f" tooltip:"Visualization only! Overrides beatmap's stack leniency, 0 disables stacking, -1 uses beatmap's value. Gameplay uses the same stacks, but scores and star rating won't match osu!" showif:"StackEnabled=true" liveedit:"false"`
+	HiddenFadeFraction  float64 `label:"Hidden fade-out point" min:"0.5" max:"1" scale:"100" format:"%.0f%%" tooltip:"Visualization only! Part of the approach time after which objects are fully faded out with Hidden, 70% is osu!'s default. Higher values keep objects visible longer for practice. Hit windows are not affected"`
+	MalformedSliders    string  `label:"Malformed sliders" combo:"Keep|Keep (like osu!),Circles|Convert to circles,Skip|Skip" tooltip:"How zero-length sliders are handled. Duplicate sliders (same time and position) are skipped unless set to Keep. Star rating always uses sliders as they are. Scores may not match osu! on affected maps" liveedit:"false"`
 	Sliders             *sliders
 	Colors              *objectColors
 }
@@ -15,6 +15,8 @@ func initPlayfield() *playfield {
 		LeadInTime:                   5,
 		LeadInHold:                   2,
 		FadeOutTime:                  5,
+		SkipLeadIn:                   0,
+		SkipThreshold:                0,
 		SeizureWarning: &seizure{
 			Enabled:  true,
 			Duration: 5,
@@ -81,9 +83,11 @@ type playfield struct {
 	ShiftY                       float64  `min:"-512" max:"512"` //offset the playfield by Y osu!pixels
 	ScaleStoryboardWithPlayfield bool     `liveedit:"false"`
 	MoveStoryboardWithPlayfield  bool     `tooltip:"Even if selected, \"Position the playfield like in osu!\" option won't affect the storyboard" liveedit:"false"`
-	LeadInTime                   float64  `max:"10" format:"%.1fs" liveedit:"false"` //5
-	LeadInHold                   float64  `max:"10" format:"%.1fs" liveedit:"false"` //2
-	FadeOutTime                  float64  `max:"10" format:"%.1fs" liveedit:"false"` //5
+	LeadInTime                   float64  `max:"10" format:"%.1fs" liveedit:"false"`                                                                                                                           //5
+	LeadInHold                   float64  `max:"10" format:"%.1fs" liveedit:"false"`                                                                                                                           //2
+	FadeOutTime                  float64  `max:"10" format:"%.1fs" liveedit:"false"`                                                                                                                           //5
+	SkipLeadIn                   float64  `label:"Skip lead-in" max:"10000" format:"%.0fms" tooltip:"Time left before the first object after skipping the intro. 0 uses 4-8 beats like osu!" liveedit:"false"` //0
+	SkipThreshold                float64  `label:"Minimum skippable intro" max:"20000" format:"%.0fms" tooltip:"Intro has to be longer than this to be skippable. 0 uses osu!'s threshold" liveedit:"false"`   //0
 	SeizureWarning               *seizure `liveedit:"false"`
 	Background                   *background
 	Logo                         *logo
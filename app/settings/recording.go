@@ -87,10 +87,12 @@ func initRecording() *recording {
 		CustomAudioSettings: &custom{
 			CustomOptions: "",
 		},
-		AudioFilters:   "",
-		OutputDir:      "videos",
-		Container:      "mp4",
-		ShowFFmpegLogs: true,
+		AudioFilters:    "",
+		OutputDir:       "videos",
+		Container:       "mp4",
+		ShowFFmpegLogs:  true,
+		SkipIntro:       false,
+		SkipIntroLeadIn: 2000,
 		MotionBlur: &motionblur{
 			Enabled:              false,
 			OversampleMultiplier: 16,
@@ -124,15 +126,22 @@ type recording struct {
 	FLACSettings        *flacSettings      `json:"flac" label:"FLAC Settings" showif:"AudioCodec=flac"`
 	CustomAudioSettings *custom            `json:"customAudio" label:"Custom Audio Settings" showif:"AudioCodec=!"`
 	//AudioOptions        string             `label:"Audio Encoder Options"`
-	AudioFilters   string `label:"FFmpeg Audio Filters"`
-	OutputDir      string `path:"Select video output directory"`
-	Container      string `combo:"mp4,mkv"`
-	ShowFFmpegLogs bool
-	MotionBlur     *motionblur
+	AudioFilters    string `label:"FFmpeg Audio Filters"`
+	OutputDir       string `path:"Select video output directory"`
+	Container       string `combo:"mp4,mkv"`
+	ShowFFmpegLogs  bool
+	SkipIntro       bool    `label:"Skip intro" tooltip:"Starts the video shortly before the first object, like pressing Space to skip"`
+	SkipIntroLeadIn float64 `label:"Skip intro lead-in" min:"0" max:"10000" format:"%.0fms" tooltip:"How much of the intro is left before the first object. Can't be shorter than object fade-in" showif:"SkipIntro=true"`
+	MotionBlur      *motionblur
 
 	outDir *string
 }
 
+// ShouldSkipIntro reports whether the intro should be skipped automatically, either by -skip flag or while recording
+func ShouldSkipIntro() bool {
+	return SKIP || (RECORD && Recording.SkipIntro)
+}
+
 func (g *recording) GetEncoderOptions() EncoderOptions {
 	switch strings.ToLower(g.Encoder) {
 	case "libx264":
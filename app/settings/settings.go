@@ -126,8 +126,10 @@ func (config *Config) migrateCursorDance() {
 
 	for _, m := range config.Dance.Spinners {
 		spinners = append(spinners, &spinner{
-			Mover:  m,
-			Radius: config.Dance.SpinnerRadius,
+			Mover:     m,
+			Radius:    config.Dance.SpinnerRadius,
+			SpinSpeed: "max",
+			RPM:       477,
 		})
 	}
 
@@ -11,10 +11,11 @@ var Skin = initSkin()
 
 func initSkin() *skin {
 	return &skin{
-		CurrentSkin:       "default",
-		FallbackSkin:      "default",
-		UseColorsFromSkin: false,
-		UseBeatmapColors:  false,
+		CurrentSkin:           "default",
+		FallbackSkin:          "default",
+		UseColorsFromSkin:     false,
+		UseBeatmapColors:      false,
+		BeatmapColorsOverride: "",
 		Cursor: &skinCursor{
 			UseSkinCursor:    false,
 			Scale:            1.0,
@@ -32,6 +33,8 @@ type skin struct {
 	UseColorsFromSkin bool
 	UseBeatmapColors  bool
 
+	BeatmapColorsOverride string `label:"Beatmap combo colors override" tooltip:"Comma separated hex colors (e.g. #FF66AA, 66CCFF) used instead of beatmap's combo colors. Invalid entries fall back to beatmap's color. Empty uses beatmap colors" liveedit:"false"`
+
 	Cursor *skinCursor
 }
 
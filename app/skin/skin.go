@@ -505,6 +505,34 @@ func FinishBeatmapColors() {
 			beatmapColors = append(beatmapColors, c.color)
 		}
 	}
+
+	if palette := parsePalette(settings.Skin.BeatmapColorsOverride, beatmapColors); len(palette) > 0 {
+		beatmapColors = palette
+	}
+}
+
+// parsePalette parses comma separated hex colors, invalid entries are replaced with fallback color at the same index or skipped if there's none
+func parsePalette(text string, fallback []color.Color) (palette []color.Color) {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	for i, entry := range strings.Split(text, ",") {
+		entry = strings.TrimPrefix(strings.TrimSpace(entry), "#")
+
+		if v, err := strconv.ParseUint(entry, 16, 32); err == nil && len(entry) == 6 {
+			palette = append(palette, color.NewRGB(float32(v>>16&0xFF)/255, float32(v>>8&0xFF)/255, float32(v&0xFF)/255))
+			continue
+		}
+
+		log.Printf("Invalid combo color override \"%s\", using beatmap color instead", entry)
+
+		if len(fallback) > 0 {
+			palette = append(palette, fallback[i%len(fallback)])
+		}
+	}
+
+	return
 }
 
 func GetColors() []color.Color {
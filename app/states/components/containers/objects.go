@@ -136,6 +136,10 @@ func (container *HitObjectContainer) preProcessQueue(time float64) {
 		for i := 0; i < len(container.objectQueue); i++ {
 			if p := container.objectQueue[i]; p.GetStartTime()-math.Max(15000, container.beatMap.Diff.Preempt) <= time {
 				if p := container.objectQueue[i]; p.GetStartTime()-math.Floor(container.beatMap.Diff.Preempt) <= time {
+					if container.approachingLimitReached(p, time) {
+						break
+					}
+
 					if _, ok := p.(*objects.Spinner); ok {
 						container.addProxy(&renderableProxy{
 							renderable:   p.(objects.Renderable),
@@ -173,6 +177,27 @@ func (container *HitObjectContainer) preProcessQueue(time float64) {
 	}
 }
 
+// approachingLimitReached checks whether showing the object would exceed Objects.MaxApproaching.
+// This only delays drawing, ruleset keeps its own queue so objects are judged at their normal times
+// even if they are not visible yet.
+func (container *HitObjectContainer) approachingLimitReached(object objects.IHitObject, time float64) bool {
+	limit := settings.Objects.MaxApproaching
+
+	if _, ok := object.(*objects.Spinner); ok || limit <= 0 {
+		return false
+	}
+
+	approaching := 0
+
+	for _, proxy := range container.renderables {
+		if !proxy.isSliderBody && proxy.depth != math.MaxFloat64 && proxy.depth > time {
+			approaching++
+		}
+	}
+
+	return approaching >= limit
+}
+
 func (container *HitObjectContainer) Draw(batch *batch.QuadBatch, baseCamera mgl32.Mat4, cameras []mgl32.Mat4, time float64, scale, alpha float32) {
 	divides := len(cameras)
 
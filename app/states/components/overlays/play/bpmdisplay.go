@@ -0,0 +1,75 @@
+package play
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/wieku/danser-go/app/rulesets/osu"
+	"github.com/wieku/danser-go/app/settings"
+	"github.com/wieku/danser-go/framework/graphics/batch"
+	"github.com/wieku/danser-go/framework/graphics/font"
+	color2 "github.com/wieku/danser-go/framework/math/color"
+	"github.com/wieku/danser-go/framework/math/vector"
+)
+
+type BPMDisplay struct {
+	bpmFont *font.Font
+	ruleset *osu.OsuRuleSet
+
+	mainBPM    float64
+	currentBPM float64
+
+	text string
+}
+
+func NewBPMDisplay(ruleset *osu.OsuRuleSet) *BPMDisplay {
+	return &BPMDisplay{
+		bpmFont:    font.GetFont("HUDFont"),
+		ruleset:    ruleset,
+		mainBPM:    ruleset.GetMainBPM(),
+		currentBPM: math.NaN(),
+	}
+}
+
+func (bpmDisplay *BPMDisplay) Update(time float64) {
+	bpm := bpmDisplay.ruleset.GetBPMAt(time)
+
+	if bpm == bpmDisplay.currentBPM {
+		return
+	}
+
+	bpmDisplay.currentBPM = bpm
+
+	if settings.Gameplay.BPMDisplay.ShowMainBPM && math.Round(bpm) != math.Round(bpmDisplay.mainBPM) {
+		bpmDisplay.text = fmt.Sprintf("%.0f BPM (%.0f)", bpm, bpmDisplay.mainBPM)
+	} else {
+		bpmDisplay.text = fmt.Sprintf("%.0f BPM", bpm)
+	}
+}
+
+func (bpmDisplay *BPMDisplay) Draw(batch *batch.QuadBatch, alpha float64) {
+	bpmSettings := settings.Gameplay.BPMDisplay
+
+	bpmAlpha := bpmSettings.Opacity * alpha
+
+	if bpmAlpha < 0.001 || !bpmSettings.Show || bpmDisplay.text == "" {
+		return
+	}
+
+	batch.ResetTransform()
+
+	scale := bpmSettings.Scale
+
+	position := vector.NewVec2d(bpmSettings.XPosition, bpmSettings.YPosition)
+	origin := vector.ParseOrigin(bpmSettings.Align)
+
+	cS := bpmSettings.Color
+
+	batch.SetColor(0, 0, 0, bpmAlpha*0.8)
+	bpmDisplay.bpmFont.DrawOriginV(batch, position.AddS(scale, scale), origin, 30*scale, true, bpmDisplay.text)
+
+	batch.SetColorM(color2.NewHSVA(float32(cS.Hue), float32(cS.Saturation), float32(cS.Value), float32(bpmAlpha)))
+	bpmDisplay.bpmFont.DrawOriginV(batch, position, origin, 30*scale, true, bpmDisplay.text)
+
+	batch.ResetTransform()
+}
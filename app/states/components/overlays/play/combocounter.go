@@ -12,6 +12,7 @@ import (
 	"github.com/wieku/danser-go/framework/math/animation"
 	"github.com/wieku/danser-go/framework/math/animation/easing"
 	"github.com/wieku/danser-go/framework/math/vector"
+	"log"
 	"math"
 )
 
@@ -23,6 +24,12 @@ type ComboCounter struct {
 
 	comboSlide *animation.Glider
 
+	rollback    *animation.Glider
+	rollingBack bool
+
+	popInEasing  easing.Easing
+	popOutEasing easing.Easing
+
 	comboBreak *bass.Sample
 
 	time         float64
@@ -46,6 +53,7 @@ func NewComboCounter() *ComboCounter {
 		mainCounter:  sprite.NewTextSprite("0x", fnt, 0, vector.NewVec2d(0, 0), vector.BottomLeft),
 		popCounter:   sprite.NewTextSprite("0x", fnt, 0, vector.NewVec2d(0, 0), vector.BottomLeft),
 		comboSlide:   animation.NewGlider(0),
+		rollback:     animation.NewGlider(0),
 		comboBreak:   audio.LoadSample("combobreak"),
 		nextTransfer: math.MaxFloat64,
 	}
@@ -60,13 +68,31 @@ func NewComboCounter() *ComboCounter {
 
 	counter.comboSlide.SetEasing(easing.OutQuad)
 
+	counter.popInEasing = getPopEasing(settings.Gameplay.ComboCounter.PopInEasing, easing.InQuad)
+	counter.popOutEasing = getPopEasing(settings.Gameplay.ComboCounter.PopOutEasing, easing.OutQuad)
+
 	return counter
 }
 
+func getPopEasing(name string, fallback easing.Easing) easing.Easing {
+	if e, ok := easing.GetEasingByName(name); ok {
+		return e
+	}
+
+	log.Printf("ComboCounter: unknown easing \"%s\", using default", name)
+
+	return fallback
+}
+
 func (counter *ComboCounter) Increase() {
 	counter.mainCounter.ClearTransformationsOfType(animation.Fade)
 	counter.mainCounter.SetAlpha(1)
 
+	if counter.rollingBack {
+		counter.rollingBack = false
+		counter.comboDisplay = counter.combo
+	}
+
 	if settings.Gameplay.ComboCounter.Static {
 		counter.combo++
 		counter.comboDisplay++
@@ -94,7 +120,11 @@ func (counter *ComboCounter) Reset() {
 
 	counter.combo = 0
 
-	if settings.Gameplay.ComboCounter.Static {
+	if duration := settings.Gameplay.ComboCounter.RollbackDuration; duration > 0 && counter.comboDisplay > 0 {
+		counter.rollback.Reset()
+		counter.rollback.AddEventS(counter.time, counter.time+duration, float64(counter.comboDisplay), 0)
+		counter.rollingBack = true
+	} else if settings.Gameplay.ComboCounter.Static {
 		counter.comboDisplay = 0
 		counter.mainCounter.SetText(fmt.Sprintf("%dx", counter.comboDisplay))
 	}
@@ -117,18 +147,45 @@ func (counter *ComboCounter) updateMain(combo int, bump bool) {
 
 	if bump {
 		counter.mainCounter.ClearTransformationsOfType(animation.Scale)
-		counter.mainCounter.AddTransform(animation.NewSingleTransform(animation.Scale, easing.InQuad, counter.time, counter.time+50, 1, 1.094))
-		counter.mainCounter.AddTransform(animation.NewSingleTransform(animation.Scale, easing.OutQuad, counter.time+50, counter.time+100, 1.094, 1))
+
+		popIn, popOut := counter.popTransforms(counter.time)
+
+		counter.mainCounter.AddTransform(popIn)
+		counter.mainCounter.AddTransform(popOut)
 	}
 }
 
+// popTransforms returns scale transforms of the main counter's pop starting at given time
+func (counter *ComboCounter) popTransforms(time float64) (popIn, popOut *animation.Transformation) {
+	half := settings.Gameplay.ComboCounter.PopDuration / 2
+	scale := settings.Gameplay.ComboCounter.PopScale
+
+	popIn = animation.NewSingleTransform(animation.Scale, counter.popInEasing, time, time+half, 1, scale)
+	popOut = animation.NewSingleTransform(animation.Scale, counter.popOutEasing, time+half, time+2*half, scale, 1)
+
+	return
+}
+
 func (counter *ComboCounter) Update(time float64) {
 	counter.delta += time - counter.time
 
+	if counter.rollingBack {
+		counter.rollback.Update(time)
+
+		if display := int(math.Ceil(counter.rollback.GetValue())); display != counter.comboDisplay {
+			counter.updateMain(display, false)
+		}
+
+		if counter.comboDisplay == 0 {
+			counter.rollingBack = false
+			counter.mainCounter.AddTransform(animation.NewSingleTransform(animation.Fade, easing.Linear, time, time+100, counter.mainCounter.GetAlpha(), 0.0))
+		}
+	}
+
 	if counter.delta >= 16.6667 {
 		counter.delta -= 16.6667
 
-		if counter.comboDisplay > counter.combo && counter.combo == 0 {
+		if !counter.rollingBack && counter.comboDisplay > counter.combo && counter.combo == 0 {
 			counter.updateMain(counter.comboDisplay-1, false)
 
 			if counter.comboDisplay == 0 {
@@ -189,9 +246,34 @@ func (counter *ComboCounter) Draw(batch *batch.QuadBatch, alpha float64) {
 	counter.popCounter.SetPosition(vector.NewVec2d(-3, origY).Scl(scl * counter.popCounter.GetScale().X))
 	counter.mainCounter.SetPosition(vector.NewVec2d(0, origY).Scl(scl * counter.mainCounter.GetScale().X))
 
-	counter.popCounter.Draw(0, batch)
-	counter.mainCounter.Draw(0, batch)
+	popAlpha, mainAlpha := counter.visibility()
+
+	if popAlpha > 0 {
+		batch.SetColor(1, 1, 1, comboAlpha*popAlpha)
+		counter.popCounter.Draw(0, batch)
+	}
+
+	if mainAlpha > 0 {
+		batch.SetColor(1, 1, 1, comboAlpha*mainAlpha)
+		counter.mainCounter.Draw(0, batch)
+	}
 
 	batch.SetColor(1, 1, 1, 1)
 	batch.ResetTransform()
 }
+
+// visibility returns alpha multipliers of the pop and main counter, counters showing combo below Gameplay.ComboCounter.MinCombo are hidden.
+// Animations keep running while hidden so the counter doesn't pop when it reaches the threshold.
+func (counter *ComboCounter) visibility() (popAlpha, mainAlpha float64) {
+	minCombo := settings.Gameplay.ComboCounter.MinCombo
+
+	if counter.combo >= minCombo {
+		popAlpha = 1
+	}
+
+	if counter.comboDisplay >= minCombo {
+		mainAlpha = 1
+	}
+
+	return
+}
@@ -0,0 +1,69 @@
+package play
+
+import (
+	"testing"
+
+	"github.com/wieku/danser-go/app/settings"
+	"github.com/wieku/danser-go/framework/math/animation/easing"
+)
+
+func TestComboPopEasing(t *testing.T) {
+	duration, scale := settings.Gameplay.ComboCounter.PopDuration, settings.Gameplay.ComboCounter.PopScale
+
+	settings.Gameplay.ComboCounter.PopDuration = 200
+	settings.Gameplay.ComboCounter.PopScale = 1.5
+
+	t.Cleanup(func() {
+		settings.Gameplay.ComboCounter.PopDuration, settings.Gameplay.ComboCounter.PopScale = duration, scale
+	})
+
+	counter := &ComboCounter{
+		popInEasing:  getPopEasing("OutBounce", easing.InQuad),
+		popOutEasing: getPopEasing("NoSuchEasing", easing.OutQuad),
+	}
+
+	popIn, popOut := counter.popTransforms(1000)
+
+	if popIn.GetStartTime() != 1000 || popIn.GetEndTime() != 1100 || popOut.GetStartTime() != 1100 || popOut.GetEndTime() != 1200 {
+		t.Fatalf("expected pop at 1000-1100-1200ms, got %.0f-%.0f and %.0f-%.0fms", popIn.GetStartTime(), popIn.GetEndTime(), popOut.GetStartTime(), popOut.GetEndTime())
+	}
+
+	if expected, actual := 1+0.5*easing.OutBounce(0.3), popIn.GetSingle(1030); actual != expected {
+		t.Errorf("expected chosen OutBounce pop-in to give %.4fx at 30%%, got %.4fx", expected, actual)
+	}
+
+	// unknown easings fall back to the default
+	if expected, actual := 1.5-0.5*easing.OutQuad(0.3), popOut.GetSingle(1130); actual != expected {
+		t.Errorf("expected default OutQuad pop-out to give %.4fx at 30%%, got %.4fx", expected, actual)
+	}
+}
+
+func TestComboCounterMinCombo(t *testing.T) {
+	minCombo := settings.Gameplay.ComboCounter.MinCombo
+
+	t.Cleanup(func() { settings.Gameplay.ComboCounter.MinCombo = minCombo })
+
+	tests := []struct {
+		minCombo     int
+		combo        int
+		comboDisplay int
+		popAlpha     float64
+		mainAlpha    float64
+	}{
+		{0, 0, 0, 1, 1},
+		{10, 9, 9, 0, 0},
+		// pop counter shows the new combo before the main one catches up
+		{10, 10, 9, 1, 0},
+		{10, 12, 12, 1, 1},
+	}
+
+	for _, tt := range tests {
+		settings.Gameplay.ComboCounter.MinCombo = tt.minCombo
+
+		counter := &ComboCounter{combo: tt.combo, comboDisplay: tt.comboDisplay}
+
+		if popAlpha, mainAlpha := counter.visibility(); popAlpha != tt.popAlpha || mainAlpha != tt.mainAlpha {
+			t.Errorf("min combo %d, combo %d/%d: expected alpha %.0f/%.0f, got %.0f/%.0f", tt.minCombo, tt.combo, tt.comboDisplay, tt.popAlpha, tt.mainAlpha, popAlpha, mainAlpha)
+		}
+	}
+}
@@ -1,13 +1,17 @@
 package play
 
 import (
+	"fmt"
+
 	"github.com/wieku/danser-go/app/beatmap/difficulty"
 	"github.com/wieku/danser-go/app/beatmap/objects"
 	"github.com/wieku/danser-go/app/rulesets/osu"
 	"github.com/wieku/danser-go/app/settings"
 	"github.com/wieku/danser-go/app/skin"
 	"github.com/wieku/danser-go/framework/graphics/batch"
+	"github.com/wieku/danser-go/framework/graphics/font"
 	"github.com/wieku/danser-go/framework/graphics/sprite"
+	"github.com/wieku/danser-go/framework/graphics/texture"
 	"github.com/wieku/danser-go/framework/math/animation"
 	"github.com/wieku/danser-go/framework/math/animation/easing"
 	color2 "github.com/wieku/danser-go/framework/math/color"
@@ -23,6 +27,7 @@ type HitResults struct {
 	diff     *difficulty.Difficulty
 	color    color2.Color
 	alpha    float64
+	pool     *spritePool
 }
 
 func NewHitResults(diff *difficulty.Difficulty) *HitResults {
@@ -35,14 +40,30 @@ func NewHitResults(diff *difficulty.Difficulty) *HitResults {
 	skin.GetFrames("hit300k", true)
 	skin.GetFrames("hit300g", true)
 
-	return &HitResults{
+	results := &HitResults{
 		bottom: sprite.NewManager(),
 		top:    sprite.NewManager(),
 		diff:   diff,
 	}
+
+	if settings.Gameplay.PoolResultSprites {
+		results.pool = newSpritePool()
+	}
+
+	return results
+}
+
+// newParticle returns a pooled sprite if pooling is enabled, returned sprite has to have ShowForever disabled
+func (results *HitResults) newParticle(tex *texture.TextureRegion, depth float64, position vector.Vector2d) *sprite.Sprite {
+	if results.pool != nil {
+		return results.pool.get(tex, depth, position, vector.Centre)
+	}
+
+	return sprite.NewSpriteSingle(tex, depth, position, vector.Centre)
 }
 
-func (results *HitResults) AddResult(time int64, result osu.HitResult, position vector.Vector2d, object objects.IHitObject) {
+// AddResult adds judgement sprites for given result. offset is the signed hit offset in ms, NaN if it's not known.
+func (results *HitResults) AddResult(time int64, result osu.HitResult, position vector.Vector2d, object objects.IHitObject, offset float64) {
 	var tex string
 	var particle string
 
@@ -73,6 +94,12 @@ func (results *HitResults) AddResult(time int64, result osu.HitResult, position
 
 	frames := skin.GetFrames(tex, true)
 
+	// Durations below are in game time, scale them so they stay the same in real time if requested
+	scl := 1.0
+	if settings.Gameplay.ScaleResultsWithSpeed {
+		scl = results.diff.Speed
+	}
+
 	particles := false
 
 	if particle != "" && len(frames) > 0 {
@@ -82,10 +109,10 @@ func (results *HitResults) AddResult(time int64, result osu.HitResult, position
 			particles = true
 
 			for i := 0; i < 150; i++ {
-				fadeOut := 500 + 700*rand.Float64()
+				fadeOut := (500 + 700*rand.Float64()) * scl
 				direction := vector.NewVec2dRad(rand.Float64()*2*math.Pi, rand.Float64()*35)
 
-				sp := sprite.NewSpriteSingle(particleTex, float64(time)+0.5, position, vector.Centre)
+				sp := results.newParticle(particleTex, float64(time)+0.5, position)
 				sp.SetAdditive(true)
 				sp.AddTransform(animation.NewSingleTransform(animation.Fade, easing.OutQuad, float64(time), float64(time)+fadeOut, 1.0, 0.0))
 				sp.AddTransform(animation.NewVectorTransformV(animation.Move, easing.OutQuad, float64(time), float64(time)+fadeOut, position, position.Add(direction)))
@@ -100,13 +127,13 @@ func (results *HitResults) AddResult(time int64, result osu.HitResult, position
 
 	hit := sprite.NewAnimation(frames, 1000.0/60, false, float64(time)+1, position, vector.Centre)
 
-	fadeIn := float64(time + difficulty.ResultFadeIn)
+	fadeIn := float64(time) + difficulty.ResultFadeIn*scl
 	if particles {
-		fadeIn = float64(time + 80)
+		fadeIn = float64(time) + 80*scl
 	}
 
-	postEmpt := float64(time + difficulty.PostEmpt)
-	fadeOut := postEmpt + float64(difficulty.ResultFadeOut)
+	postEmpt := float64(time) + difficulty.PostEmpt*scl
+	fadeOut := postEmpt + difficulty.ResultFadeOut*scl
 
 	hit.AddTransformUnordered(animation.NewSingleTransform(animation.Fade, easing.Linear, float64(time), fadeIn, 0.0, 1.0))
 	hit.AddTransformUnordered(animation.NewSingleTransform(animation.Fade, easing.Linear, postEmpt, fadeOut, 1.0, 0.0))
@@ -115,9 +142,9 @@ func (results *HitResults) AddResult(time int64, result osu.HitResult, position
 		if particles {
 			hit.AddTransformUnordered(animation.NewSingleTransform(animation.Scale, easing.Linear, float64(time), fadeOut, 0.9, 1.05))
 		} else {
-			hit.AddTransformUnordered(animation.NewSingleTransform(animation.Scale, easing.Linear, float64(time), float64(time+difficulty.ResultFadeIn*0.8), 0.6, 1.1))
-			hit.AddTransformUnordered(animation.NewSingleTransform(animation.Scale, easing.Linear, fadeIn, float64(time+difficulty.ResultFadeIn*1.2), 1.1, 0.9))
-			hit.AddTransformUnordered(animation.NewSingleTransform(animation.Scale, easing.Linear, float64(time+difficulty.ResultFadeIn*1.2), float64(time+difficulty.ResultFadeIn*1.4), 0.9, 1.0))
+			hit.AddTransformUnordered(animation.NewSingleTransform(animation.Scale, easing.Linear, float64(time), float64(time)+difficulty.ResultFadeIn*0.8*scl, 0.6, 1.1))
+			hit.AddTransformUnordered(animation.NewSingleTransform(animation.Scale, easing.Linear, fadeIn, float64(time)+difficulty.ResultFadeIn*1.2*scl, 1.1, 0.9))
+			hit.AddTransformUnordered(animation.NewSingleTransform(animation.Scale, easing.Linear, float64(time)+difficulty.ResultFadeIn*1.2*scl, float64(time)+difficulty.ResultFadeIn*1.4*scl, 0.9, 1.0))
 		}
 
 		if result == osu.Miss {
@@ -134,8 +161,17 @@ func (results *HitResults) AddResult(time int64, result osu.HitResult, position
 	hit.AdjustTimesToTransformations()
 	hit.ResetValuesToTransforms()
 
+	// Misses and 300s keep skin's colors
+	if settings.Gameplay.TintResultsWithCombo && result&(osu.Hit100|osu.Hit50) > 0 {
+		hit.SetColor(skin.GetColor(int(object.GetComboSet()), int(object.GetComboSetHax()), results.color))
+	}
+
 	results.top.Add(hit)
 
+	if settings.Gameplay.ShowHitOffset && result&(osu.Hit100|osu.Hit50) > 0 && !math.IsNaN(offset) {
+		results.addOffsetText(time, offset, position, float64(time)+difficulty.ResultFadeIn*scl, postEmpt, fadeOut)
+	}
+
 	if !settings.Gameplay.ShowHitLighting || result&osu.BaseHitsM < osu.Hit50 {
 		return
 	}
@@ -143,17 +179,63 @@ func (results *HitResults) AddResult(time int64, result osu.HitResult, position
 	lighting := sprite.NewSpriteSingle(skin.GetTexture("lighting"), float64(time), position, vector.Centre)
 	lighting.SetColor(skin.GetColor(int(object.GetComboSet()), int(object.GetComboSetHax()), results.color))
 	lighting.SetAdditive(true)
-	lighting.AddTransformUnordered(animation.NewSingleTransform(animation.Scale, easing.OutQuad, float64(time), float64(time+600), 0.8, 1.2))
-	lighting.AddTransformUnordered(animation.NewSingleTransform(animation.Fade, easing.Linear, float64(time), float64(time+200), 0, 1))
-	lighting.AddTransformUnordered(animation.NewSingleTransform(animation.Fade, easing.Linear, float64(time+400), float64(time+1400), 1, 0))
+	lighting.AddTransformUnordered(animation.NewSingleTransform(animation.Scale, easing.OutQuad, float64(time), float64(time)+600*scl, 0.8, 1.2))
+	lighting.AddTransformUnordered(animation.NewSingleTransform(animation.Fade, easing.Linear, float64(time), float64(time)+200*scl, 0, 1))
+	lighting.AddTransformUnordered(animation.NewSingleTransform(animation.Fade, easing.Linear, float64(time)+400*scl, float64(time)+1400*scl, 1, 0))
 
 	results.bottom.Add(lighting)
 }
 
+// AddTickPulse spawns a small expanding pulse at the position of a hit slider tick
+func (results *HitResults) AddTickPulse(time int64, position vector.Vector2d) {
+	tex := skin.GetTexture("sliderscorepoint")
+	if tex == nil {
+		return
+	}
+
+	scl := 1.0
+	if settings.Gameplay.ScaleResultsWithSpeed {
+		scl = results.diff.Speed
+	}
+
+	endTime := float64(time) + 200*scl
+
+	pulse := sprite.NewSpriteSingle(tex, float64(time), position, vector.Centre)
+	pulse.SetAdditive(true)
+	pulse.AddTransformUnordered(animation.NewSingleTransform(animation.Fade, easing.OutQuad, float64(time), endTime, 0.8, 0.0))
+	pulse.AddTransformUnordered(animation.NewSingleTransform(animation.Scale, easing.OutQuad, float64(time), endTime, 1.0, 2.5))
+	pulse.SortTransformations()
+	pulse.AdjustTimesToTransformations()
+	pulse.ResetValuesToTransforms()
+
+	results.top.Add(pulse)
+}
+
+func (results *HitResults) addOffsetText(time int64, offset float64, position vector.Vector2d, fadeIn, postEmpt, fadeOut float64) {
+	color := color2.NewRGB(1, 0.4, 0.4)
+	if offset < 0 {
+		color = color2.NewRGB(0.4, 0.6, 1)
+	}
+
+	text := sprite.NewTextSpriteSize(fmt.Sprintf("%+.0fms", offset), font.GetFont("HUDFont"), 20, float64(time)+2, position.AddS(0, 40), vector.Centre)
+	text.SetColor(color)
+	text.AddTransformUnordered(animation.NewSingleTransform(animation.Fade, easing.Linear, float64(time), fadeIn, 0.0, 1.0))
+	text.AddTransformUnordered(animation.NewSingleTransform(animation.Fade, easing.Linear, postEmpt, fadeOut, 1.0, 0.0))
+	text.SortTransformations()
+	text.AdjustTimesToTransformations()
+	text.ResetValuesToTransforms()
+
+	results.top.Add(text)
+}
+
 func (results *HitResults) Update(time float64) {
 	results.bottom.Update(time)
 	results.top.Update(time)
 	results.lastTime = time
+
+	if results.pool != nil {
+		results.pool.update(time)
+	}
 }
 
 func (results *HitResults) DrawBottom(batch *batch.QuadBatch, c []color2.Color, alpha float64) {
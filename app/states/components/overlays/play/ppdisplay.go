@@ -2,6 +2,7 @@ package play
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 
 	"github.com/wieku/danser-go/app/beatmap/difficulty"
@@ -95,7 +96,12 @@ func (ppDisplay *PPDisplay) updatePP(glider *animation.TargetGlider, text *strin
 	glider.SetDecimals(settings.Gameplay.PPCounter.Decimals)
 	glider.Update(time)
 
-	*text = fmt.Sprintf(ppDisplay.format, glider.GetValue())
+	if settings.Gameplay.PPCounter.RoundLive {
+		// fmt rounds half to even, we want half up
+		*text = fmt.Sprintf("%.0fpp", math.Floor(glider.GetValue()+0.5))
+	} else {
+		*text = fmt.Sprintf(ppDisplay.format, glider.GetValue())
+	}
 
 	if len(*text) > len(*mText) {
 		*mText = *text
@@ -138,13 +144,44 @@ func (ppDisplay *PPDisplay) Draw(batch *batch.QuadBatch, alpha float64) {
 		}
 
 		ppDisplay.drawPP(batch, "Total:", ppDisplay.ppText, position.AddS(0, (120+offset)*ppScale), length, ppScale, color, vector.TopLeft)
+		ppDisplay.drawGoal(batch, position.AddS(length, (160+offset)*ppScale), ppScale, color, vector.TopLeft)
 	} else {
 		ppDisplay.drawPP(batch, "", ppDisplay.ppText, position, 0, ppScale, color, origin)
+		ppDisplay.drawGoal(batch, position.AddS(0, 36*ppScale), ppScale, color, origin)
 	}
 
 	batch.ResetTransform()
 }
 
+func (ppDisplay *PPDisplay) drawGoal(batch *batch.QuadBatch, position vector.Vector2d, ppScale float64, color color2.Color, origin vector.Vector2d) {
+	delta, ok := goalDelta(ppDisplay.ppGlider.GetValue(), settings.Gameplay.PPCounter.Goal)
+	if !ok {
+		return
+	}
+
+	text := fmt.Sprintf("Goal: %.0fpp (%+.0fpp)", settings.Gameplay.PPCounter.Goal, delta)
+
+	batch.SetColor(0, 0, 0, float64(color.A)*0.8)
+	ppDisplay.ppFont.DrawOriginV(batch, position.AddS(ppScale, ppScale), origin, 20*ppScale, true, text)
+
+	if delta >= 0 {
+		batch.SetColor(0.4, 1, 0.4, float64(color.A))
+	} else {
+		batch.SetColorM(color)
+	}
+
+	ppDisplay.ppFont.DrawOriginV(batch, position, origin, 20*ppScale, true, text)
+}
+
+// goalDelta returns how much pp is above (positive) or below (negative) the goal, ok is false if goal is not set
+func goalDelta(pp, goal float64) (delta float64, ok bool) {
+	if goal <= 0 {
+		return 0, false
+	}
+
+	return pp - goal, true
+}
+
 func (ppDisplay *PPDisplay) drawPP(batch *batch.QuadBatch, title, ppXText string, position vector.Vector2d, length float64, ppScale float64, color color2.Color, origin vector.Vector2d) {
 	if title != "" {
 		batch.SetColor(0, 0, 0, float64(color.A)*0.8)
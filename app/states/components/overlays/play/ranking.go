@@ -137,7 +137,17 @@ func NewRankingPanel(cursor *graphics.Cursor, ruleset *osu.OsuRuleSet, hitError
 
 	score := panel.ruleset.GetScore(panel.cursor)
 
-	panel.pp = fmt.Sprintf("%."+strconv.Itoa(settings.Gameplay.PPCounter.Decimals)+"fpp", score.PP)
+	ppDecimals := settings.Gameplay.PPCounter.Decimals
+	if settings.Gameplay.PPCounter.RoundLive {
+		ppDecimals = 2
+	}
+
+	panel.pp = fmt.Sprintf("%."+strconv.Itoa(ppDecimals)+"fpp", score.PP)
+
+	// converted modes don't award pp, show what a perfect play would give instead
+	if ssPP := panel.ruleset.GetConvertSSPP(panel.cursor); ssPP > 0 {
+		panel.pp = fmt.Sprintf("SS: %."+strconv.Itoa(ppDecimals)+"fpp", ssPP)
+	}
 
 	panel.gradeS = sprite.NewSpriteSingle(skin.GetTexture("ranking-"+score.Grade.TextureName()), 5, rRPos, vector.Centre)
 
@@ -175,7 +185,7 @@ func NewRankingPanel(cursor *graphics.Cursor, ruleset *osu.OsuRuleSet, hitError
 	bMap := ruleset.GetBeatMap()
 
 	panel.beatmapName = fmt.Sprintf("%s - %s [%s]", bMap.Artist, bMap.Name, bMap.Difficulty)
-	panel.beatmapCreator = fmt.Sprintf("Beatmap by %s", bMap.Creator)
+	panel.beatmapCreator = fmt.Sprintf("Beatmap by %s (%.2f*)", bMap.Creator, ruleset.GetStars(cursor))
 
 	scoreTime := panel.cursor.ScoreTime
 	if settings.Gameplay.ResultsUseLocalTimeZone {
@@ -186,7 +196,12 @@ func NewRankingPanel(cursor *graphics.Cursor, ruleset *osu.OsuRuleSet, hitError
 
 	panel.score = fmt.Sprintf("%08d", score.Score)
 	panel.maxCombo = fmt.Sprintf("%dx", score.Combo)
-	panel.accuracy = fmt.Sprintf("%.2f%%", score.Accuracy)
+	accuracy := ruleset.GetDisplayAccuracy(cursor)
+	if ruleset.GetScoreVersion(cursor) == 2 {
+		accuracy = osu.FloorAccuracy(accuracy)
+	}
+
+	panel.accuracy = fmt.Sprintf("%.2f%%", accuracy)
 
 	panel.hpGraph = make([]vector.Vector2d, len(hpGraph))
 	copy(panel.hpGraph, hpGraph)
@@ -0,0 +1,54 @@
+package play
+
+import (
+	"github.com/wieku/danser-go/framework/graphics/sprite"
+	"github.com/wieku/danser-go/framework/graphics/texture"
+	"github.com/wieku/danser-go/framework/math/vector"
+)
+
+// poolGrace delays reuse of finished sprites so that the draw thread won't see them being reinitialized
+const poolGrace = 100.0
+
+// spritePool reuses short-lived sprites once their transforms are finished
+type spritePool struct {
+	free   []*sprite.Sprite
+	active []*sprite.Sprite
+}
+
+func newSpritePool() *spritePool {
+	return &spritePool{}
+}
+
+func (pool *spritePool) get(tex *texture.TextureRegion, depth float64, position vector.Vector2d, origin vector.Vector2d) (sp *sprite.Sprite) {
+	if n := len(pool.free); n > 0 {
+		sp = pool.free[n-1]
+		pool.free = pool.free[:n-1]
+
+		sp.Reset(tex, depth, position, origin)
+	} else {
+		sp = sprite.NewSpriteSingle(tex, depth, position, origin)
+	}
+
+	pool.active = append(pool.active, sp)
+
+	return
+}
+
+func (pool *spritePool) update(time float64) {
+	n := 0
+
+	for _, sp := range pool.active {
+		if time >= sp.GetEndTime()+poolGrace {
+			pool.free = append(pool.free, sp)
+		} else {
+			pool.active[n] = sp
+			n++
+		}
+	}
+
+	for i := n; i < len(pool.active); i++ {
+		pool.active[i] = nil
+	}
+
+	pool.active = pool.active[:n]
+}
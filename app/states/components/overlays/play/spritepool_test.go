@@ -0,0 +1,51 @@
+package play
+
+import (
+	"testing"
+
+	"github.com/wieku/danser-go/framework/graphics/sprite"
+	"github.com/wieku/danser-go/framework/math/animation"
+	"github.com/wieku/danser-go/framework/math/animation/easing"
+	"github.com/wieku/danser-go/framework/math/vector"
+)
+
+// BenchmarkResultSprites simulates a stream of judgements 50ms apart, each spawning 150 particles living for 1s
+func BenchmarkResultSprites(b *testing.B) {
+	b.Run("New", func(b *testing.B) {
+		benchmarkResultSprites(b, nil)
+	})
+
+	b.Run("Pooled", func(b *testing.B) {
+		benchmarkResultSprites(b, newSpritePool())
+	})
+}
+
+func benchmarkResultSprites(b *testing.B, pool *spritePool) {
+	b.ReportAllocs()
+
+	position := vector.NewVec2d(256, 192)
+
+	for i := 0; i < b.N; i++ {
+		time := float64(i * 50)
+
+		if pool != nil {
+			pool.update(time)
+		}
+
+		for j := 0; j < 150; j++ {
+			var sp *sprite.Sprite
+
+			if pool != nil {
+				sp = pool.get(nil, time+0.5, position, vector.Centre)
+			} else {
+				sp = sprite.NewSpriteSingle(nil, time+0.5, position, vector.Centre)
+			}
+
+			sp.SetAdditive(true)
+			sp.AddTransform(animation.NewSingleTransform(animation.Fade, easing.OutQuad, time, time+1000, 1.0, 0.0))
+			sp.ResetValuesToTransforms()
+			sp.AdjustTimesToTransformations()
+			sp.ShowForever(false)
+		}
+	}
+}
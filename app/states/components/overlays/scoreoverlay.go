@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
 	"github.com/wieku/danser-go/app/audio"
 	"github.com/wieku/danser-go/app/beatmap"
 	"github.com/wieku/danser-go/app/beatmap/difficulty"
@@ -70,6 +71,13 @@ type ScoreOverlay struct {
 	ScaledWidth  float64
 	ScaledHeight float64
 	camera       *camera2.Camera
+	projection   mgl32.Mat4
+
+	kiaiPulse float64
+
+	fitProjection    mgl32.Mat4 // HUD camera fitted into the screen, base for SetViewport
+	screenProjection mgl32.Mat4 // whole screen, for elements that ignore HUD aspect ratio
+	screenWidth      float64
 
 	keyFont    *font.Font
 	scoreFont  *font.Font
@@ -77,6 +85,13 @@ type ScoreOverlay struct {
 
 	bgDim *animation.Glider
 
+	missFlash *animation.Glider
+
+	hudEndFade   *animation.Glider
+	hudFadeStart bool
+
+	sliderHeadOffsets map[int64]float64
+
 	hitErrorMeter *play.HitErrorMeter
 
 	aimErrorMeter *play.AimErrorMeter
@@ -94,6 +109,7 @@ type ScoreOverlay struct {
 
 	entry         *play.ScoreBoard
 	audioTime     float64
+	drainProgress float64
 	normalTime    float64
 	breakMode     bool
 	currentBreak  *beatmap.Pause
@@ -108,6 +124,8 @@ type ScoreOverlay struct {
 
 	comboCounter *play.ComboCounter
 
+	tickSample *bass.Sample
+
 	hpBar *play.HpBar
 
 	arrows *sprite.Manager
@@ -125,6 +143,7 @@ type ScoreOverlay struct {
 
 	hitCounts   *play.HitDisplay
 	ppDisplay   *play.PPDisplay
+	bpmDisplay  *play.BPMDisplay
 	strainGraph *play.StrainGraph
 
 	underlay *sprite.Sprite
@@ -173,7 +192,8 @@ func NewScoreOverlay(ruleset *osu.OsuRuleSet, cursor *graphics.Cursor) *ScoreOve
 	overlay.beatmapEnd = math.Inf(1)
 
 	overlay.ScaledHeight = 768
-	overlay.ScaledWidth = settings.Graphics.GetAspectRatio() * overlay.ScaledHeight
+	overlay.ScaledWidth = settings.Gameplay.GetHUDAspectRatio() * overlay.ScaledHeight
+	overlay.screenWidth = settings.Graphics.GetAspectRatio() * overlay.ScaledHeight
 
 	overlay.initUnderlay()
 
@@ -185,6 +205,7 @@ func NewScoreOverlay(ruleset *osu.OsuRuleSet, cursor *graphics.Cursor) *ScoreOve
 	overlay.accuracyGlider = animation.NewTargetGlider(100, 2)
 
 	overlay.ppDisplay = play.NewPPDisplay(ruleset.GetBeatMap().Diff.Mods, settings.Gameplay.UseLazerPP)
+	overlay.bpmDisplay = play.NewBPMDisplay(ruleset)
 
 	overlay.strainGraph = play.NewStrainGraph(ruleset)
 
@@ -192,6 +213,11 @@ func NewScoreOverlay(ruleset *osu.OsuRuleSet, cursor *graphics.Cursor) *ScoreOve
 
 	overlay.bgDim = animation.NewGlider(1)
 
+	overlay.missFlash = animation.NewGlider(0)
+	overlay.hudEndFade = animation.NewGlider(1)
+
+	overlay.sliderHeadOffsets = make(map[int64]float64)
+
 	audio.LoadSample("sectionpass")
 	audio.LoadSample("sectionfail")
 
@@ -224,6 +250,17 @@ func NewScoreOverlay(ruleset *osu.OsuRuleSet, cursor *graphics.Cursor) *ScoreOve
 	overlay.camera.SetViewportF(0, int(overlay.ScaledHeight), int(overlay.ScaledWidth), 0)
 	overlay.camera.Update()
 
+	screenCamera := camera2.NewCamera()
+	screenCamera.SetViewportF(0, int(overlay.ScaledHeight), int(overlay.screenWidth), 0)
+	screenCamera.Update()
+
+	overlay.screenProjection = screenCamera.GetProjectionView()
+
+	sX, sY := hudFitScale(overlay.ScaledWidth/overlay.ScaledHeight, overlay.screenWidth/overlay.ScaledHeight)
+
+	overlay.fitProjection = mgl32.Scale3D(float32(sX), float32(sY), 1).Mul4(overlay.camera.GetProjectionView())
+	overlay.projection = overlay.fitProjection
+
 	overlay.keyOverlay = sprite.NewManager()
 
 	keyBg := sprite.NewSpriteSingle(skin.GetTexture("inputoverlay-background"), 0, vector.NewVec2d(overlay.ScaledWidth, overlay.ScaledHeight/2-64), vector.TopLeft)
@@ -234,7 +271,7 @@ func NewScoreOverlay(ruleset *osu.OsuRuleSet, cursor *graphics.Cursor) *ScoreOve
 	overlay.keyOverlay.Add(keyBg)
 
 	for i := 0; i < 4; i++ {
-		posY := overlay.ScaledHeight/2 - 64 + (30.4+float64(i)*47.2)*settings.Gameplay.KeyOverlay.Scale
+		posY := overlay.ScaledHeight/2 - 64 + (30.4+float64(keySlot(i))*47.2)*settings.Gameplay.KeyOverlay.Scale
 
 		key := sprite.NewSpriteSingle(skin.GetTexture("inputoverlay-key"), 1, vector.NewVec2d(overlay.ScaledWidth-24*settings.Gameplay.KeyOverlay.Scale, posY), vector.Centre)
 		key.ShowForever(true)
@@ -250,7 +287,9 @@ func NewScoreOverlay(ruleset *osu.OsuRuleSet, cursor *graphics.Cursor) *ScoreOve
 	showAfterSkip := 2000.0
 
 	beatLen := overlay.ruleset.GetBeatMap().Timings.GetPointAt(0).GetBaseBeatLength()
-	if beatLen > 0 {
+	if settings.Playfield.SkipLeadIn > 0 {
+		showAfterSkip = settings.Playfield.SkipLeadIn
+	} else if beatLen > 0 {
 		showAfterSkip = beatLen
 		if beatLen < 500 {
 			showAfterSkip *= 8
@@ -261,7 +300,12 @@ func NewScoreOverlay(ruleset *osu.OsuRuleSet, cursor *graphics.Cursor) *ScoreOve
 
 	overlay.skipTo = overlay.ruleset.GetBeatMap().HitObjects[0].GetStartTime() - showAfterSkip
 
-	if !settings.SKIP && overlay.skipTo > 1200+math.Min(1800, overlay.ruleset.GetBeatMap().Diff.Preempt) {
+	skipThreshold := 1200 + math.Min(1800, overlay.ruleset.GetBeatMap().Diff.Preempt)
+	if settings.Playfield.SkipThreshold > 0 {
+		skipThreshold = settings.Playfield.SkipThreshold
+	}
+
+	if !settings.ShouldSkipIntro() && overlay.skipTo > skipThreshold {
 		skipFrames := skin.GetFrames("play-skip", true)
 		overlay.skip = sprite.NewAnimation(skipFrames, skin.GetInfo().GetFrameTime(len(skipFrames)), true, 0.0, vector.NewVec2d(overlay.ScaledWidth, overlay.ScaledHeight), vector.BottomRight)
 		overlay.skip.SetAlpha(0.0)
@@ -271,6 +315,11 @@ func NewScoreOverlay(ruleset *osu.OsuRuleSet, cursor *graphics.Cursor) *ScoreOve
 
 	overlay.comboCounter = play.NewComboCounter()
 
+	overlay.tickSample = audio.LoadSample("slidertick-feedback")
+	if overlay.tickSample == nil {
+		overlay.tickSample = audio.LoadSample("normal-slidertick")
+	}
+
 	overlay.hpBar = play.NewHpBar()
 
 	overlay.hitCounts = play.NewHitDisplay(overlay.ruleset, overlay.cursor)
@@ -327,15 +376,28 @@ func (overlay *ScoreOverlay) initUnderlay() {
 func (overlay *ScoreOverlay) hitReceived(c *graphics.Cursor, time int64, number int64, position vector.Vector2d, result osu.HitResult, comboResult osu.ComboResult, ppResults osu.PerformanceResult, _ int64) {
 	object := overlay.ruleset.GetBeatMap().HitObjects[number]
 
-	if result&(osu.BaseHitsM) > 0 {
-		overlay.results.AddResult(time, result, position, object)
-	}
-
 	_, hC := object.(*objects.Circle)
 	allowCircle := hC && (result&(osu.BaseHits|osu.PositionalMiss) > 0)
 	_, sl := object.(*objects.Slider)
 	allowSlider := sl && (result&(osu.SliderStart|osu.PositionalMiss)) > 0
 
+	if sl && result&osu.SliderStart > 0 {
+		overlay.sliderHeadOffsets[number] = float64(time) - object.GetStartTime()
+	}
+
+	if result&(osu.BaseHitsM) > 0 {
+		offset := math.NaN()
+
+		if hC {
+			offset = float64(time) - object.GetStartTime()
+		} else if hOffset, ok := overlay.sliderHeadOffsets[number]; ok {
+			offset = hOffset
+			delete(overlay.sliderHeadOffsets, number)
+		}
+
+		overlay.results.AddResult(time, result, position, object, offset)
+	}
+
 	if allowCircle || allowSlider {
 		timeDiff := float64(time) - object.GetStartTime()
 
@@ -356,12 +418,22 @@ func (overlay *ScoreOverlay) hitReceived(c *graphics.Cursor, time int64, number
 		return
 	}
 
+	if result&osu.SliderPoint > 0 {
+		overlay.tickFeedback(time, position)
+	}
+
 	if comboResult == osu.Increase {
 		overlay.comboCounter.Increase()
 	} else if comboResult == osu.Reset {
 		overlay.comboCounter.Reset()
 	}
 
+	if result == osu.Miss && settings.Gameplay.MissFlash.Enabled {
+		// Restart the flash instead of stacking it so miss streams don't turn the screen red
+		overlay.missFlash.Reset()
+		overlay.missFlash.AddEventSEase(overlay.normalTime, overlay.normalTime+settings.Gameplay.MissFlash.Duration, settings.Gameplay.MissFlash.Intensity, 0, easing.OutQuad)
+	}
+
 	if overlay.flashlight != nil {
 		overlay.flashlight.UpdateCombo(int64(overlay.comboCounter.GetCombo()))
 	}
@@ -375,7 +447,7 @@ func (overlay *ScoreOverlay) hitReceived(c *graphics.Cursor, time int64, number
 	}
 
 	overlay.scoreGlider.SetValue(float64(sc.Score), settings.Gameplay.Score.StaticScore)
-	overlay.accuracyGlider.SetValue(sc.Accuracy, settings.Gameplay.Score.StaticAccuracy)
+	overlay.accuracyGlider.SetValue(overlay.ruleset.GetDisplayAccuracy(overlay.cursor), settings.Gameplay.Score.StaticAccuracy)
 
 	overlay.ppDisplay.Add(ppResults)
 
@@ -396,6 +468,18 @@ func (overlay *ScoreOverlay) hitReceived(c *graphics.Cursor, time int64, number
 	}
 }
 
+func (overlay *ScoreOverlay) tickFeedback(time int64, position vector.Vector2d) {
+	feedback := settings.Gameplay.SliderTickFeedback
+
+	if feedback.Pulse {
+		overlay.results.AddTickPulse(time, position)
+	}
+
+	if feedback.Sound && overlay.tickSample != nil && !overlay.audioDisabled {
+		overlay.tickSample.PlayV(feedback.Volume * settings.Audio.GeneralVolume * settings.Audio.SampleVolume)
+	}
+}
+
 func (overlay *ScoreOverlay) Update(time float64) {
 	if overlay.audioTime == 0 {
 		overlay.audioTime = time
@@ -440,10 +524,33 @@ func (overlay *ScoreOverlay) Update(time float64) {
 	overlay.arrows.Update(overlay.audioTime)
 	overlay.strainGraph.Update(overlay.audioTime)
 
+	overlay.updateKiai(overlay.audioTime)
+
 	//normal timing
 	overlay.updateNormal(overlay.normalTime)
 }
 
+func (overlay *ScoreOverlay) updateKiai(time float64) {
+	overlay.kiaiPulse = 0
+
+	if !settings.Gameplay.KiaiEffects {
+		return
+	}
+
+	timing := overlay.ruleset.GetTimingAt(int64(time))
+	if !timing.Kiai || timing.BeatLength <= 0 {
+		return
+	}
+
+	// Pulse peaks on each beat and fades out until the next one
+	phase := math.Mod(time-timing.BeatStart, timing.BeatLength) / timing.BeatLength
+	if phase < 0 {
+		phase += 1
+	}
+
+	overlay.kiaiPulse = 1 - easing.OutQuad(phase)
+}
+
 func (overlay *ScoreOverlay) updateNormal(time float64) {
 	overlay.updateBreaks(time)
 
@@ -502,6 +609,7 @@ func (overlay *ScoreOverlay) updateNormal(time float64) {
 	overlay.scoreGlider.Update(time)
 	overlay.accuracyGlider.Update(time)
 	overlay.ppDisplay.Update(time)
+	overlay.bpmDisplay.Update(time)
 	overlay.hitCounts.Update(time)
 
 	var currentStates [4]bool
@@ -542,6 +650,14 @@ func (overlay *ScoreOverlay) updateNormal(time float64) {
 
 	overlay.keyOverlay.Update(time)
 	overlay.bgDim.Update(time)
+	overlay.missFlash.Update(time)
+
+	if settings.Gameplay.HUDEndFade.Enabled && !overlay.hudFadeStart && overlay.ruleset.IsEnded() {
+		overlay.hudFadeStart = true
+		overlay.hudEndFade.AddEventS(time, time+settings.Gameplay.HUDEndFade.Duration, 1, 0)
+	}
+
+	overlay.hudEndFade.Update(time)
 
 	overlay.resultsFade.Update(time)
 
@@ -587,11 +703,56 @@ func (overlay *ScoreOverlay) SetMusic(music bass.ITrack) {
 	overlay.music = music
 }
 
+// SetViewport confines the HUD to given rectangle, in the same scaled coordinates the HUD uses for full screen.
+// Layout is scaled uniformly and centered in the rectangle, so it won't get stretched if aspect ratios differ.
+func (overlay *ScoreOverlay) SetViewport(x, y, width, height float64) {
+	overlay.projection = viewportProjection(overlay.fitProjection, overlay.ScaledWidth, overlay.ScaledHeight, x, y, width, height)
+}
+
+// viewportProjection scales fit projection of HUD with given size down to the rectangle and moves it to rectangle's centre.
+// Rectangle is transformed by fit projection as well, so pillarboxing or letterboxing of the HUD is taken into account.
+func viewportProjection(fit mgl32.Mat4, hudWidth, hudHeight, x, y, width, height float64) mgl32.Mat4 {
+	scale := math.Min(width/hudWidth, height/hudHeight)
+
+	// Rectangle's and HUD's centres in normalized device coordinates
+	center := fit.Mul4x1(mgl32.Vec4{float32(x + width/2), float32(y + height/2), 0, 1})
+	hudCenter := fit.Mul4x1(mgl32.Vec4{float32(hudWidth / 2), float32(hudHeight / 2), 0, 1})
+
+	return mgl32.Translate3D(center.X()-hudCenter.X()*float32(scale), center.Y()-hudCenter.Y()*float32(scale), 0).Mul4(mgl32.Scale3D(float32(scale), float32(scale), 1)).Mul4(fit)
+}
+
+// hudFitScale returns NDC scale that fits HUD of hudAspect into the screen of screenAspect without stretching.
+// Narrower HUD is pillarboxed, wider HUD is letterboxed, both are centered.
+func hudFitScale(hudAspect, screenAspect float64) (float64, float64) {
+	if hudAspect < screenAspect {
+		return hudAspect / screenAspect, 1
+	}
+
+	return 1, screenAspect / hudAspect
+}
+
+// hudDimAlpha returns HUD opacity for given background dim, interpolating from maxAlpha at no dim to minAlpha at full dim
+func hudDimAlpha(dim, minAlpha, maxAlpha float64) float64 {
+	return mutils.ClampF(maxAlpha+(minAlpha-maxAlpha)*mutils.ClampF(dim, 0, 1), 0, 1)
+}
+
 func (overlay *ScoreOverlay) DrawBackground(batch *batch.QuadBatch, c []color2.Color, alpha float64) {
 	overlay.boundaries.Draw(batch.Projection, float32(overlay.ruleset.GetBeatMap().Diff.CircleRadius), float32(alpha*overlay.bgDim.GetValue()))
 }
 
 func (overlay *ScoreOverlay) DrawBeforeObjects(batch *batch.QuadBatch, c []color2.Color, alpha float64) {
+	if flashAlpha := overlay.missFlash.GetValue() * alpha; flashAlpha > 0.001 {
+		batch.Flush()
+
+		w, h := float32(overlay.screenWidth), float32(overlay.ScaledHeight)
+
+		overlay.shapeRenderer.SetCamera(overlay.screenProjection)
+		overlay.shapeRenderer.SetColor(1, 0, 0, flashAlpha)
+		overlay.shapeRenderer.Begin()
+		overlay.shapeRenderer.DrawQuad(0, 0, w, 0, w, h, 0, h)
+		overlay.shapeRenderer.End()
+	}
+
 	overlay.results.DrawBottom(batch, c, alpha)
 }
 
@@ -609,10 +770,10 @@ func (overlay *ScoreOverlay) DrawNormal(batch *batch.QuadBatch, _ []color2.Color
 	}
 
 	prev := batch.Projection
-	batch.SetCamera(overlay.camera.GetProjectionView())
+	batch.SetCamera(overlay.projection)
 
-	overlay.hitErrorMeter.Draw(batch, alpha)
-	overlay.aimErrorMeter.Draw(batch, alpha)
+	overlay.hitErrorMeter.Draw(batch, alpha*overlay.hudEndFade.GetValue())
+	overlay.aimErrorMeter.Draw(batch, alpha*overlay.hudEndFade.GetValue())
 
 	batch.SetScale(1, 1)
 	batch.SetColor(1, 1, 1, alpha)
@@ -625,8 +786,14 @@ func (overlay *ScoreOverlay) DrawNormal(batch *batch.QuadBatch, _ []color2.Color
 }
 
 func (overlay *ScoreOverlay) DrawHUD(batch *batch.QuadBatch, _ []color2.Color, alpha float64) {
+	alpha *= overlay.hudEndFade.GetValue()
+
+	if dimOpacity := settings.Gameplay.HUDDimOpacity; dimOpacity.Enabled {
+		alpha *= hudDimAlpha(settings.Playfield.Background.Dim.Normal, dimOpacity.MinOpacity, dimOpacity.MaxOpacity)
+	}
+
 	prev := batch.Projection
-	batch.SetCamera(overlay.camera.GetProjectionView())
+	batch.SetCamera(overlay.projection)
 	batch.ResetTransform()
 
 	if !settings.Gameplay.Underlay.AboveHpBar {
@@ -634,6 +801,8 @@ func (overlay *ScoreOverlay) DrawHUD(batch *batch.QuadBatch, _ []color2.Color, a
 		overlay.underlay.Draw(0, batch)
 	}
 
+	overlay.drawKiaiGlow(batch, alpha)
+
 	overlay.entry.Draw(batch, alpha)
 
 	overlay.passContainer.Draw(overlay.audioTime, batch)
@@ -649,6 +818,7 @@ func (overlay *ScoreOverlay) DrawHUD(batch *batch.QuadBatch, _ []color2.Color, a
 	}
 
 	overlay.drawKeys(batch, alpha)
+	overlay.drawSpinnerProgress(batch, alpha)
 
 	batch.ResetTransform()
 	batch.SetColor(1, 1, 1, alpha)
@@ -664,17 +834,42 @@ func (overlay *ScoreOverlay) DrawHUD(batch *batch.QuadBatch, _ []color2.Color, a
 	}
 
 	overlay.ppDisplay.Draw(batch, alpha)
+	overlay.bpmDisplay.Draw(batch, alpha)
 	overlay.strainGraph.Draw(batch, alpha)
 	overlay.hitCounts.Draw(batch, alpha)
 
 	if overlay.panel != nil {
 		settings.Playfield.Bloom.Enabled = false
+
+		batch.SetCamera(overlay.screenProjection)
 		overlay.panel.Draw(batch, overlay.resultsFade.GetValue())
 	}
 
 	batch.SetCamera(prev)
 }
 
+func (overlay *ScoreOverlay) drawKiaiGlow(batch *batch.QuadBatch, alpha float64) {
+	glowAlpha := 0.15 * overlay.kiaiPulse * alpha
+	if glowAlpha < 0.001 {
+		return
+	}
+
+	batch.Flush()
+
+	const thickness = 6
+
+	w, h := float32(overlay.screenWidth), float32(overlay.ScaledHeight)
+
+	overlay.shapeRenderer.SetCamera(overlay.screenProjection)
+	overlay.shapeRenderer.SetColor(1, 1, 1, glowAlpha)
+	overlay.shapeRenderer.Begin()
+	overlay.shapeRenderer.DrawQuad(0, 0, w, 0, w, thickness, 0, thickness)
+	overlay.shapeRenderer.DrawQuad(0, h-thickness, w, h-thickness, w, h, 0, h)
+	overlay.shapeRenderer.DrawQuad(0, thickness, thickness, thickness, thickness, h-thickness, 0, h-thickness)
+	overlay.shapeRenderer.DrawQuad(w-thickness, thickness, w, thickness, w, h-thickness, w-thickness, h-thickness)
+	overlay.shapeRenderer.End()
+}
+
 func (overlay *ScoreOverlay) drawScore(batch *batch.QuadBatch, alpha float64) {
 	scoreAlpha := settings.Gameplay.Score.Opacity * alpha
 
@@ -701,7 +896,7 @@ func (overlay *ScoreOverlay) drawScore(batch *batch.QuadBatch, alpha float64) {
 
 	batch.Flush()
 
-	overlay.shapeRenderer.SetCamera(overlay.camera.GetProjectionView())
+	overlay.shapeRenderer.SetCamera(overlay.projection)
 
 	if settings.Gameplay.Score.ProgressBar == "Pie" {
 		if progress < 0.0 {
@@ -758,7 +953,12 @@ func (overlay *ScoreOverlay) drawScore(batch *batch.QuadBatch, alpha float64) {
 	scoreText := fmt.Sprintf("%08d", int64(math.Round(overlay.scoreGlider.GetValue())))
 	overlay.scoreFont.DrawOrigin(batch, overlay.ScaledWidth+rightOffset+scoreOverlap+xOff, yOff, vector.TopRight, scoreSize, true, scoreText)
 
-	accText := fmt.Sprintf("%5.2f%%", overlay.accuracyGlider.GetValue())
+	accuracy := overlay.accuracyGlider.GetValue()
+	if overlay.ruleset.GetScoreVersion(overlay.cursor) == 2 {
+		accuracy = osu.FloorAccuracy(accuracy)
+	}
+
+	accText := fmt.Sprintf("%5.2f%%", accuracy)
 	overlay.scoreFont.DrawOrigin(batch, overlay.ScaledWidth+rightOffset+accOverlap+xOff, accYPos+yOff, vector.TopRight, accSize, true, accText)
 
 	batch.ResetTransform()
@@ -773,6 +973,15 @@ func (overlay *ScoreOverlay) drawScore(batch *batch.QuadBatch, alpha float64) {
 	}
 }
 
+// keySlot returns the vertical slot of the key, keys 0-1 are K1/K2 and 2-3 are M1/M2
+func keySlot(key int) int {
+	if settings.Gameplay.KeyOverlay.MouseOnTop {
+		return (key + 2) % 4
+	}
+
+	return key
+}
+
 func (overlay *ScoreOverlay) drawKeys(batch *batch.QuadBatch, alpha float64) {
 	keyAlpha := settings.Gameplay.KeyOverlay.Opacity * alpha
 
@@ -796,7 +1005,7 @@ func (overlay *ScoreOverlay) drawKeys(batch *batch.QuadBatch, alpha float64) {
 
 	for i := 0; i < 4; i++ {
 		posX := overlay.ScaledWidth - 24*keyScale
-		posY := overlay.ScaledHeight/2 - 64 + (30.4+float64(i)*47.2)*keyScale
+		posY := overlay.ScaledHeight/2 - 64 + (30.4+float64(keySlot(i))*47.2)*keyScale
 		scale := overlay.keys[i].GetScale().Y * keyScale
 
 		text := strconv.Itoa(overlay.keyCounters[i])
@@ -822,6 +1031,38 @@ func (overlay *ScoreOverlay) drawKeys(batch *batch.QuadBatch, alpha float64) {
 	batch.ResetTransform()
 }
 
+func (overlay *ScoreOverlay) drawSpinnerProgress(batch *batch.QuadBatch, alpha float64) {
+	if !settings.Gameplay.ShowSpinnerProgress || overlay.failed {
+		return
+	}
+
+	progress := overlay.ruleset.GetSpinnerProgress(overlay.cursor)
+	if !progress.Active {
+		return
+	}
+
+	text := fmt.Sprintf("SPIN! %d/%d", mutils.Min(progress.Rotations, progress.Required), progress.Required)
+
+	batch.ResetTransform()
+
+	if progress.Cleared {
+		text = "CLEAR!"
+
+		if progress.Bonus > 0 {
+			text += fmt.Sprintf(" +%d", progress.Bonus*1000)
+		}
+
+		batch.SetColor(0.4, 1, 0.4, alpha)
+	} else {
+		batch.SetColor(1, 1, 1, alpha)
+	}
+
+	overlay.keyFont.DrawOrigin(batch, overlay.ScaledWidth/2, overlay.ScaledHeight*0.8, vector.Centre, 24, true, text)
+
+	batch.ResetTransform()
+	batch.SetColor(1, 1, 1, alpha)
+}
+
 func (overlay *ScoreOverlay) getProgress() float64 {
 	hObjects := overlay.ruleset.GetBeatMap().HitObjects
 	startTime := hObjects[0].GetStartTime()
@@ -829,12 +1070,23 @@ func (overlay *ScoreOverlay) getProgress() float64 {
 
 	musicPos := overlay.audioTime
 
-	progress := mutils.ClampF((musicPos-startTime)/(endTime-startTime), 0.0, 1.0)
 	if musicPos < startTime {
-		progress = mutils.ClampF(-1.0+musicPos/startTime, -1.0, 0.0)
+		if startTime <= 0 {
+			return 0
+		}
+
+		return mutils.ClampF(-1.0+musicPos/startTime, -1.0, 0.0)
 	}
 
-	return progress
+	progress := 1.0
+	if endTime > startTime {
+		progress = mutils.ClampF((musicPos-startTime)/(endTime-startTime), 0.0, 1.0)
+	}
+
+	// Audio position may stall or jump back once the song ends while objects remain, don't let the bar go back
+	overlay.drainProgress = math.Max(overlay.drainProgress, progress)
+
+	return overlay.drainProgress
 }
 
 func (overlay *ScoreOverlay) isDrain() bool {
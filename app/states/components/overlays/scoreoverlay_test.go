@@ -0,0 +1,45 @@
+package overlays
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestViewportProjection(t *testing.T) {
+	const hudWidth, hudHeight = 1024.0, 768.0
+
+	tests := []struct {
+		name         string
+		screenAspect float64
+		x, y, w, h   float64
+		// Expected top-left and bottom-right corners of the HUD in HUD coordinates of the full screen layout
+		minX, minY, maxX, maxY float64
+	}{
+		{"full screen", 4.0 / 3, 0, 0, hudWidth, hudHeight, 0, 0, hudWidth, hudHeight},
+		{"left half", 4.0 / 3, 0, 0, 512, 768, 0, 192, 512, 576},
+		{"left half, pillarboxed", 16.0 / 9, 0, 0, 512, 768, 0, 192, 512, 576},
+		{"bottom right quarter, pillarboxed", 16.0 / 9, 512, 384, 512, 384, 512, 384, hudWidth, hudHeight},
+		{"wide rectangle, letterboxed", 1, 100, 100, 800, 200, 366.67, 100, 633.33, 300},
+	}
+
+	for _, tt := range tests {
+		sX, sY := hudFitScale(hudWidth/hudHeight, tt.screenAspect)
+		fit := mgl32.Scale3D(float32(sX), float32(sY), 1).Mul4(mgl32.Ortho2D(0, hudWidth, hudHeight, 0))
+
+		projection := viewportProjection(fit, hudWidth, hudHeight, tt.x, tt.y, tt.w, tt.h)
+
+		check := func(corner string, hudX, hudY, wantX, wantY float64) {
+			got := projection.Mul4x1(mgl32.Vec4{float32(hudX), float32(hudY), 0, 1})
+			want := fit.Mul4x1(mgl32.Vec4{float32(wantX), float32(wantY), 0, 1})
+
+			if math.Abs(float64(got.X()-want.X())) > 1e-3 || math.Abs(float64(got.Y()-want.Y())) > 1e-3 {
+				t.Errorf("%s: %s corner at (%.3f, %.3f), want (%.3f, %.3f)", tt.name, corner, got.X(), got.Y(), want.X(), want.Y())
+			}
+		}
+
+		check("top-left", 0, 0, tt.minX, tt.minY)
+		check("bottom-right", hudWidth, hudHeight, tt.maxX, tt.maxY)
+	}
+}
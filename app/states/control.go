@@ -0,0 +1,215 @@
+package states
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/wieku/danser-go/app/dance"
+	"github.com/wieku/danser-go/app/rulesets/osu"
+	"github.com/wieku/danser-go/framework/bass"
+	"github.com/wieku/danser-go/framework/goroutines"
+	"log"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Control channel commands, one JSON object per line on stdin:
+//
+//	{"command": "pause"}
+//	{"command": "resume"}
+//	{"command": "seek", "time": 12000}
+//	{"command": "query-score"}
+//	{"command": "speed", "speed": 0.75}
+//
+// Each command is answered with one JSON object per line on stdout.
+const (
+	cmdPause      = "pause"
+	cmdResume     = "resume"
+	cmdSeek       = "seek"
+	cmdQueryScore = "query-score"
+	cmdSpeed      = "speed"
+)
+
+// maxPlaybackSpeed is the highest speed accepted by the speed command
+const maxPlaybackSpeed = 4.0
+
+type controlCommand struct {
+	Command string   `json:"command"`
+	Time    *float64 `json:"time,omitempty"`  // Seek target in milliseconds
+	Speed   *float64 `json:"speed,omitempty"` // Playback speed multiplier
+}
+
+type controlResponse struct {
+	Command string     `json:"command"`
+	Error   string     `json:"error,omitempty"`
+	Time    float64    `json:"time"`
+	Paused  bool       `json:"paused"`
+	Speed   float64    `json:"speed"`
+	Score   *osu.Score `json:"score,omitempty"`
+}
+
+var stdoutMutex sync.Mutex
+
+func parseControlCommand(line string) (*controlCommand, error) {
+	cmd := new(controlCommand)
+
+	if err := json.Unmarshal([]byte(line), cmd); err != nil {
+		return nil, fmt.Errorf("malformed command: %w", err)
+	}
+
+	cmd.Command = strings.ToLower(strings.TrimSpace(cmd.Command))
+
+	switch cmd.Command {
+	case cmdPause, cmdResume, cmdQueryScore:
+	case cmdSeek:
+		if cmd.Time == nil {
+			return nil, errors.New("seek: missing time")
+		}
+
+		if *cmd.Time < 0 {
+			return nil, fmt.Errorf("seek: invalid time: %.0f", *cmd.Time)
+		}
+	case cmdSpeed:
+		if cmd.Speed == nil {
+			return nil, errors.New("speed: missing speed")
+		}
+
+		if *cmd.Speed <= 0 || *cmd.Speed > maxPlaybackSpeed {
+			return nil, fmt.Errorf("speed: invalid speed: %.2f", *cmd.Speed)
+		}
+	case "":
+		return nil, errors.New("missing command")
+	default:
+		return nil, fmt.Errorf("unknown command: %s", cmd.Command)
+	}
+
+	return cmd, nil
+}
+
+// startControlChannel starts reading commands from stdin, they are executed on the update thread
+func (player *Player) startControlChannel() {
+	player.commands = make(chan *controlCommand, 16)
+
+	goroutines.Run(func() {
+		scanner := bufio.NewScanner(os.Stdin)
+
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+
+			cmd, err := parseControlCommand(line)
+			if err != nil {
+				writeControlResponse(&controlResponse{Error: err.Error()})
+				continue
+			}
+
+			player.commands <- cmd
+		}
+
+		if err := scanner.Err(); err != nil {
+			log.Println("Control channel closed:", err)
+		}
+	})
+}
+
+func (player *Player) processCommands() {
+	if player.commands == nil {
+		return
+	}
+
+	for {
+		select {
+		case cmd := <-player.commands:
+			writeControlResponse(player.executeCommand(cmd))
+		default:
+			return
+		}
+	}
+}
+
+func (player *Player) executeCommand(cmd *controlCommand) *controlResponse {
+	resp := &controlResponse{Command: cmd.Command}
+
+	switch cmd.Command {
+	case cmdPause:
+		if player.musicPlayer.GetState() != bass.MusicPlaying {
+			resp.Error = "music is not playing"
+			break
+		}
+
+		player.musicPlayer.Pause()
+	case cmdResume:
+		if player.musicPlayer.GetState() != bass.MusicPaused {
+			resp.Error = "music is not paused"
+			break
+		}
+
+		player.musicPlayer.Play()
+	case cmdSeek:
+		// Ruleset can't be rewound, so only seeking forward is allowed
+		if *cmd.Time <= player.progressMsF {
+			resp.Error = fmt.Sprintf("can't seek backwards, current time: %.0fms", player.progressMsF)
+			break
+		}
+
+		if !player.start {
+			resp.Error = "music has not started yet"
+			break
+		}
+
+		player.musicPlayer.SetPosition((*cmd.Time - (player.progressMsF - player.rawPositionF)) / 1000)
+	case cmdSpeed:
+		if player.progressMsF >= player.mapEndL {
+			resp.Error = "map has already ended"
+			break
+		}
+
+		player.setPlaybackSpeed(*cmd.Speed)
+	case cmdQueryScore:
+		if ruleset := player.getRuleset(); ruleset != nil {
+			score := ruleset.GetScore(player.controller.GetCursors()[0])
+			resp.Score = &score
+		} else {
+			resp.Error = "no ruleset is running"
+		}
+	}
+
+	resp.Time = player.progressMsF
+	resp.Paused = player.musicPlayer.GetState() == bass.MusicPaused
+	resp.Speed = player.speedGlider.GetValue()
+
+	return resp
+}
+
+// setPlaybackSpeed smoothly changes music tempo. Game clock advances by real time scaled by tempo
+// and the ruleset is stepped every millisecond of game time, so judgements don't depend on playback speed.
+func (player *Player) setPlaybackSpeed(speed float64) {
+	player.speedGlider.AddEventS(player.progressMsF, player.progressMsF+100, player.speedGlider.GetValue(), speed)
+}
+
+func (player *Player) getRuleset() *osu.OsuRuleSet {
+	if rC, ok := player.controller.(*dance.ReplayController); ok {
+		return rC.GetRuleset()
+	} else if rP, ok := player.controller.(*dance.PlayerController); ok {
+		return rP.GetRuleset()
+	}
+
+	return nil
+}
+
+func writeControlResponse(resp *controlResponse) {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		log.Println("Failed to serialize control response:", err)
+		return
+	}
+
+	stdoutMutex.Lock()
+	defer stdoutMutex.Unlock()
+
+	fmt.Fprintln(os.Stdout, string(data))
+}
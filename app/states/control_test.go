@@ -0,0 +1,59 @@
+package states
+
+import "testing"
+
+func TestParseControlCommand(t *testing.T) {
+	tests := []struct {
+		line    string
+		command string
+		time    float64
+		speed   float64
+		wantErr bool
+	}{
+		{line: `{"command": "pause"}`, command: cmdPause},
+		{line: `{"command": " RESUME "}`, command: cmdResume},
+		{line: `{"command": "query-score"}`, command: cmdQueryScore},
+		{line: `{"command": "seek", "time": 12000}`, command: cmdSeek, time: 12000},
+		{line: `{"command": "seek", "time": 0}`, command: cmdSeek, time: 0},
+		{line: `{"command": "speed", "speed": 0.75}`, command: cmdSpeed, speed: 0.75},
+		{line: `{"command": "speed", "speed": 4}`, command: cmdSpeed, speed: 4},
+		{line: `{"command": "seek"}`, wantErr: true},
+		{line: `{"command": "seek", "time": -1}`, wantErr: true},
+		{line: `{"command": "speed"}`, wantErr: true},
+		{line: `{"command": "speed", "speed": 0}`, wantErr: true},
+		{line: `{"command": "speed", "speed": 4.5}`, wantErr: true},
+		{line: `{"command": "jump"}`, wantErr: true},
+		{line: `{}`, wantErr: true},
+		{line: `pause`, wantErr: true},
+		{line: `{"command": "seek", "time": "10"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		cmd, err := parseControlCommand(tt.line)
+
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseControlCommand(%s) expected an error, got %+v", tt.line, cmd)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("parseControlCommand(%s) unexpected error: %s", tt.line, err)
+			continue
+		}
+
+		if cmd.Command != tt.command {
+			t.Errorf("parseControlCommand(%s) command = %q, want %q", tt.line, cmd.Command, tt.command)
+		}
+
+		if cmd.Time != nil && *cmd.Time != tt.time {
+			t.Errorf("parseControlCommand(%s) time = %.0f, want %.0f", tt.line, *cmd.Time, tt.time)
+		}
+
+		if cmd.Speed != nil && *cmd.Speed != tt.speed {
+			t.Errorf("parseControlCommand(%s) speed = %.2f, want %.2f", tt.line, *cmd.Speed, tt.speed)
+		}
+	}
+}
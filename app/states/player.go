@@ -11,7 +11,6 @@ import (
 	"github.com/wieku/danser-go/app/discord"
 	"github.com/wieku/danser-go/app/graphics"
 	"github.com/wieku/danser-go/app/input"
-	"github.com/wieku/danser-go/app/rulesets/osu"
 	"github.com/wieku/danser-go/app/settings"
 	"github.com/wieku/danser-go/app/states/components/common"
 	"github.com/wieku/danser-go/app/states/components/containers"
@@ -123,6 +122,10 @@ type Player struct {
 	failing bool
 	failAt  float64
 	failed  bool
+
+	commands chan *controlCommand
+
+	focusPaused bool
 }
 
 func NewPlayer(beatMap *beatmap.BeatMap) *Player {
@@ -294,14 +297,23 @@ func NewPlayer(beatMap *beatmap.BeatMap) *Player {
 
 	player.trySetupFail()
 
+	if r := player.getRuleset(); r != nil {
+		r.SetAudioLength(player.musicPlayer.GetLength() * 1000)
+	}
+
 	preempt := math.Min(1800, beatMap.Diff.Preempt)
 
 	skipTime := 0.0
-	if settings.SKIP {
-		skipTime = beatMap.HitObjects[0].GetStartTime()
+	if settings.ShouldSkipIntro() {
+		leadIn := settings.Playfield.SkipLeadIn
+		if !settings.SKIP {
+			leadIn = settings.Recording.SkipIntroLeadIn
+		}
+
+		skipTime = getSkipTarget(beatMap.HitObjects[0].GetStartTime(), preempt, leadIn)
 	}
 
-	skipTime = math.Max(skipTime, settings.START*1000) - preempt
+	skipTime = math.Max(skipTime, settings.START*1000-preempt)
 
 	beatmapStart := math.Max(beatMap.HitObjects[0].GetStartTime(), settings.START*1000) - preempt
 	beatmapEnd := beatMap.HitObjects[len(beatMap.HitObjects)-1].GetEndTime() + float64(beatMap.Diff.Hit50)
@@ -487,6 +499,10 @@ func NewPlayer(beatMap *beatmap.BeatMap) *Player {
 		return player
 	}
 
+	if settings.CONTROL {
+		player.startControlChannel()
+	}
+
 	goroutines.RunOS(func() {
 		var lastTimeNano = qpc.GetNanoTime()
 
@@ -536,6 +552,8 @@ func NewPlayer(beatMap *beatmap.BeatMap) *Player {
 
 			player.updateMain(delta)
 
+			player.processCommands()
+
 			lastTimeNano = currentTimeNano
 
 			player.updateLimiter.Sync()
@@ -550,15 +568,11 @@ func NewPlayer(beatMap *beatmap.BeatMap) *Player {
 
 func (player *Player) trySetupFail() {
 	if sO, ok := player.overlay.(*overlays.ScoreOverlay); ok {
-		var ruleset *osu.OsuRuleSet
-
-		if rC, ok1 := player.controller.(*dance.ReplayController); ok1 {
-			ruleset = rC.GetRuleset()
-		} else if rP, ok2 := player.controller.(*dance.PlayerController); ok2 {
-			ruleset = rP.GetRuleset()
-		}
+		if ruleset := player.getRuleset(); ruleset != nil {
+			ruleset.SetDesyncListener(func(cursor *graphics.Cursor, ratio float64) {
+				log.Printf("WARNING: %.0f%% of early objects were missed or shaken in %s's replay, it may have been played on a different version of the map!", ratio*100, cursor.Name)
+			})
 
-		if ruleset != nil {
 			ruleset.SetFailListener(func(cursor *graphics.Cursor) {
 				if !settings.RECORD {
 					audio.PlayFailSound()
@@ -597,9 +611,14 @@ func (player *Player) trySetupFail() {
 }
 
 func (player *Player) Update(delta float64) bool {
+	player.updateFocusPause()
+
 	speed := 1.0
 
-	if player.musicPlayer.GetState() == bass.MusicPlaying {
+	if player.focusPaused {
+		// Clock stands still, so objects aren't judged and HP doesn't drain
+		speed = 0
+	} else if player.musicPlayer.GetState() == bass.MusicPlaying {
 		speed = player.musicPlayer.GetTempo() * player.musicPlayer.GetRelativeFrequency()
 	} else if !(player.progressMsF < player.startPointE || player.start) {
 		speed = settings.SPEED
@@ -626,6 +645,18 @@ func (player *Player) Update(delta float64) bool {
 	return false
 }
 
+func (player *Player) updateFocusPause() {
+	shouldPause := settings.Input.PauseOnFocusLoss && settings.PLAY && !settings.RECORD && !input.Focused && player.start && !player.failed && player.progressMsF < player.mapEndL
+
+	if shouldPause && !player.focusPaused {
+		player.musicPlayer.Pause()
+		player.focusPaused = true
+	} else if !shouldPause && player.focusPaused {
+		player.musicPlayer.Play()
+		player.focusPaused = false
+	}
+}
+
 func (player *Player) GetTime() float64 {
 	return player.progressMsF
 }
@@ -1080,4 +1111,14 @@ func (player *Player) Show() {}
 
 func (player *Player) Hide() {}
 
-func (player *Player) Dispose() {}
+func (player *Player) Dispose() {
+	if ruleset := player.getRuleset(); ruleset != nil {
+		ruleset.Dispose()
+	}
+}
+
+// getSkipTarget returns the time to which the intro is skipped.
+// Lead-in can't be shorter than preempt, otherwise the first object would pop in.
+func getSkipTarget(firstObjectStart, preempt, leadIn float64) float64 {
+	return firstObjectStart - math.Max(preempt, leadIn)
+}
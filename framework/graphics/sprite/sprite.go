@@ -53,6 +53,27 @@ func NewSpriteSingle(tex *texture.TextureRegion, depth float64, position vector.
 	}
 }
 
+// Reset restores the sprite to the state of a newly created one while keeping allocated memory, allows pooling of short-lived sprites
+func (sprite *Sprite) Reset(tex *texture.TextureRegion, depth float64, position vector.Vector2d, origin vector.Vector2d) {
+	sprite.Texture = tex
+	sprite.transforms = sprite.transforms[:0]
+	sprite.startTime = 0
+	sprite.endTime = 0
+	sprite.showForever = true
+	sprite.depth = depth
+	sprite.position = position
+	sprite.origin = origin
+	sprite.scale = vector.NewVec2d(1, 1)
+	sprite.flipX = false
+	sprite.flipY = false
+	sprite.rotation = 0
+	sprite.color = color2.NewL(1)
+	sprite.additive = false
+	sprite.cutX = 0
+	sprite.cutY = 0
+	sprite.cutOrigin = vector.Vector2d{}
+}
+
 func (sprite *Sprite) Update(time float64) {
 	for i := 0; i < len(sprite.transforms); i++ {
 		transform := sprite.transforms[i]
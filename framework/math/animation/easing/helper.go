@@ -44,3 +44,28 @@ func GetEasing(easingID int64) Easing {
 	}
 	return easings[easingID]
 }
+
+var easingNames = map[string]Easing{
+	"Linear":     Linear,
+	"InQuad":     InQuad,
+	"OutQuad":    OutQuad,
+	"InOutQuad":  InOutQuad,
+	"InCubic":    InCubic,
+	"OutCubic":   OutCubic,
+	"InOutCubic": InOutCubic,
+	"InSine":     InSine,
+	"OutSine":    OutSine,
+	"InOutSine":  InOutSine,
+	"InExpo":     InExpo,
+	"OutExpo":    OutExpo,
+	"InBack":     InBack,
+	"OutBack":    OutBack,
+	"OutElastic": OutElastic,
+	"OutBounce":  OutBounce,
+}
+
+// GetEasingByName returns easing with given name, ok is false if the name is not known
+func GetEasingByName(name string) (easing Easing, ok bool) {
+	easing, ok = easingNames[name]
+	return
+}
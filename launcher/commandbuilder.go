@@ -58,6 +58,8 @@ type builder struct {
 
 	config string
 
+	profileName string
+
 	knockoutReplays []*knockoutReplay
 }
 
@@ -120,6 +122,66 @@ func (b *builder) setMap(bMap *beatmap.BeatMap) {
 	b.offset.changed = b.offset.value != 0
 }
 
+func (b *builder) createProfile(name string) *paramProfile {
+	profile := &paramProfile{Name: name}
+
+	getF := func(p floatParam) *float32 {
+		if !p.changed {
+			return nil
+		}
+
+		v := p.value
+
+		return &v
+	}
+
+	profile.Speed = getF(b.speed)
+	profile.Pitch = getF(b.pitch)
+	profile.AR = getF(b.ar)
+	profile.OD = getF(b.od)
+	profile.CS = getF(b.cs)
+	profile.HP = getF(b.hp)
+
+	if b.offset.changed {
+		v := b.offset.value
+		profile.Offset = &v
+	}
+
+	return profile
+}
+
+func (b *builder) applyProfile(profile *paramProfile) {
+	setF := func(p *floatParam, v *float32) {
+		if v == nil {
+			p.value = p.ogValue
+			p.changed = false
+
+			return
+		}
+
+		p.value = *v
+		p.changed = math32.Abs(p.value-p.ogValue) > 0.001
+	}
+
+	setF(&b.speed, profile.Speed)
+	setF(&b.pitch, profile.Pitch)
+
+	if b.currentMap != nil {
+		setF(&b.ar, profile.AR)
+		setF(&b.od, profile.OD)
+		setF(&b.cs, profile.CS)
+		setF(&b.hp, profile.HP)
+	}
+
+	if profile.Offset != nil {
+		b.offset.value = *profile.Offset
+	} else {
+		b.offset.value = b.offset.ogValue
+	}
+
+	b.offset.changed = b.offset.value != b.offset.ogValue
+}
+
 func (b *builder) numKnockoutReplays() (ret int) {
 	if b.knockoutReplays != nil {
 		for _, r := range b.knockoutReplays {
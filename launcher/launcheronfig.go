@@ -37,6 +37,19 @@ type launcherConf struct {
 	SkipMapUpdate    bool
 	ShowJSONPaths    bool
 	LastKnockoutPath string
+	ParamProfiles    []*paramProfile
+}
+
+// paramProfile holds a named set of map parameters, only changed values are stored
+type paramProfile struct {
+	Name   string
+	Speed  *float32 `json:",omitempty"`
+	Pitch  *float32 `json:",omitempty"`
+	AR     *float32 `json:",omitempty"`
+	OD     *float32 `json:",omitempty"`
+	CS     *float32 `json:",omitempty"`
+	HP     *float32 `json:",omitempty"`
+	Offset *int32   `json:",omitempty"`
 }
 
 func loadLauncherConfig() {
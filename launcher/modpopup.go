@@ -9,6 +9,9 @@ type modPopup struct {
 	*popup
 
 	bld *builder
+
+	modText  string
+	modError string
 }
 
 func newModPopup(bld *builder) *modPopup {
@@ -61,6 +64,8 @@ func (m *modPopup) drawModMenu() {
 		imgui.EndTable()
 	}
 
+	m.drawModInput()
+
 	centerTable("modresettable", -1, func() {
 		if imgui.Button("Reset##Mods") {
 			m.bld.mods = difficulty.None
@@ -143,3 +148,41 @@ func (m *modPopup) modCheckboxMulti(mod1, mod2, incompat difficulty.Modifier) {
 		m.modCheckbox(mod2, incompat)
 	}
 }
+
+func (m *modPopup) drawModInput() {
+	if imgui.BeginTable("mstrtable", 2) {
+		imgui.TableSetupColumnV("c1mstr", imgui.TableColumnFlagsWidthFixed, 0, uint(0))
+		imgui.TableSetupColumnV("c2mstr", imgui.TableColumnFlagsWidthStretch, 0, uint(1))
+
+		imgui.TableNextColumn()
+
+		imgui.AlignTextToFramePadding()
+		imgui.Text("Mod string:")
+
+		imgui.TableNextColumn()
+
+		imgui.SetNextItemWidth(-1)
+
+		if imgui.InputTextWithHint("##modstring", "e.g. HDDT", &m.modText) {
+			if mods, err := difficulty.ParseModsStrict(m.modText); err != nil {
+				m.modError = err.Error()
+			} else {
+				m.modError = ""
+				m.bld.mods = mods
+			}
+		} else if !imgui.IsItemActive() && m.modError == "" {
+			m.modText = m.bld.mods.String()
+		}
+
+		if m.modError != "" {
+			imgui.TableNextColumn()
+			imgui.TableNextColumn()
+
+			imgui.PushStyleColor(imgui.StyleColorText, vec4(1, 0.3, 0.3, 1))
+			imgui.Text(m.modError)
+			imgui.PopStyleColor()
+		}
+
+		imgui.EndTable()
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/wieku/danser-go/framework/platform"
 	"github.com/wieku/danser-go/framework/util"
 	"strconv"
+	"strings"
 )
 
 func drawTimeMenu(bld *builder) {
@@ -35,6 +36,8 @@ func drawTimeMenu(bld *builder) {
 	imgui.Dummy(vec2(0, iPos2-iPos1))
 
 	sliderIntReset("Audio offset", &bld.offset, -300, 300, "%dms")
+
+	drawProfileMenu(bld)
 }
 
 func drawSpeedMenu(bld *builder) {
@@ -61,6 +64,89 @@ func drawParamMenu(bld *builder) {
 		sliderFloatReset("Health Drain (HP)", &bld.hp, 0, 10, "%.1f")
 		imgui.Spacing()
 	}
+
+	drawProfileMenu(bld)
+}
+
+func drawProfileMenu(bld *builder) {
+	imgui.Separator()
+
+	if imgui.BeginTable("pfa", 3) {
+		imgui.TableSetupColumnV("c1pfa", imgui.TableColumnFlagsWidthFixed, 0, uint(0))
+		imgui.TableSetupColumnV("c2pfa", imgui.TableColumnFlagsWidthStretch, 0, uint(1))
+		imgui.TableSetupColumnV("c3pfa", imgui.TableColumnFlagsWidthFixed, 0, uint(2))
+
+		imgui.TableNextColumn()
+
+		imgui.AlignTextToFramePadding()
+		imgui.Text("Profile:")
+
+		imgui.TableNextColumn()
+
+		imgui.SetNextItemWidth(-1)
+
+		toDelete := -1
+
+		if imgui.BeginCombo("##profilecombo", "Load profile...") {
+			for i, profile := range launcherConfig.ParamProfiles {
+				if imgui.Selectable(profile.Name + "##profile" + strconv.Itoa(i)) {
+					bld.applyProfile(profile)
+				}
+
+				if imgui.BeginPopupContextItem() {
+					if imgui.Selectable("Delete##profiledel" + strconv.Itoa(i)) {
+						toDelete = i
+					}
+
+					imgui.EndPopup()
+				}
+			}
+
+			imgui.EndCombo()
+		}
+
+		if toDelete >= 0 {
+			launcherConfig.ParamProfiles = append(launcherConfig.ParamProfiles[:toDelete], launcherConfig.ParamProfiles[toDelete+1:]...)
+			saveLauncherConfig()
+		}
+
+		imgui.TableNextColumn()
+		imgui.TableNextColumn()
+
+		imgui.TableNextColumn()
+
+		imgui.SetNextItemWidth(-1)
+		imgui.InputTextWithHint("##profilename", "Profile name", &bld.profileName)
+
+		imgui.TableNextColumn()
+
+		if name := strings.TrimSpace(bld.profileName); imgui.Button("Save##profilesave") && name != "" {
+			saveProfile(bld.createProfile(name))
+			bld.profileName = ""
+		}
+
+		imgui.EndTable()
+	}
+}
+
+// saveProfile adds the profile to launcher config, replacing the one with the same name
+func saveProfile(profile *paramProfile) {
+	replaced := false
+
+	for i, p := range launcherConfig.ParamProfiles {
+		if p.Name == profile.Name {
+			launcherConfig.ParamProfiles[i] = profile
+			replaced = true
+
+			break
+		}
+	}
+
+	if !replaced {
+		launcherConfig.ParamProfiles = append(launcherConfig.ParamProfiles, profile)
+	}
+
+	saveLauncherConfig()
 }
 
 func drawCDMenu(bld *builder) {
@@ -505,6 +505,11 @@ func (m *songSelectPopup) showMapTooltip(bMap *beatmap.BeatMap) {
 		sR = mutils.FormatWOZeros(bMap.Stars, 2)
 	}
 
+	aimTap := "N/A"
+	if bMap.AimTapRatio > 0 {
+		aimTap = mutils.FormatWOZeros(bMap.AimTapRatio, 2)
+	}
+
 	bpm := fmt.Sprintf("%.0f", bMap.MinBPM)
 	if math.Abs(bMap.MinBPM-bMap.MaxBPM) > 0.01 {
 		bpm = fmt.Sprintf("%.0f - %.0f", bMap.MinBPM, bMap.MaxBPM)
@@ -522,7 +527,7 @@ func (m *songSelectPopup) showMapTooltip(bMap *beatmap.BeatMap) {
 		}
 
 		tRow("Stars: ", sR)
-		tRow("", "")
+		tRow("Aim/Tap: ", aimTap)
 
 		tRow("Objects: ", "%d", bMap.Circles+bMap.Sliders+bMap.Spinners)
 		tRow("AR: ", mutils.FormatWOZeros(bMap.Diff.GetAR(), 2))